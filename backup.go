@@ -0,0 +1,424 @@
+package contacts
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	backupFormat  = "sendgrid-contactdb-backup"
+	backupVersion = 1
+)
+
+// backupHeader is the first record of a backup archive.
+type backupHeader struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// backupRecord is a single line of a backup archive. Exactly one field is set.
+type backupRecord struct {
+	CustomField *CustomField     `json:"custom_field,omitempty"`
+	List        *List            `json:"list,omitempty"`
+	Segment     *Segment         `json:"segment,omitempty"`
+	Recipient   *backupRecipient `json:"recipient,omitempty"`
+}
+
+type backupCustomFieldValue struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+type backupRecipient struct {
+	ID           string                   `json:"id"`
+	Email        string                   `json:"email"`
+	FirstName    string                   `json:"first_name"`
+	LastName     string                   `json:"last_name"`
+	CreatedAt    int                      `json:"created_at"`
+	CustomFields []backupCustomFieldValue `json:"custom_fields,omitempty"`
+	ListIDs      []uint                   `json:"list_ids,omitempty"`
+}
+
+// Backup writes a snapshot of the contactdb to w: custom field definitions, lists, segments and their
+// conditions, and every recipient with its custom field values and list memberships. The archive is a
+// versioned, gzipped stream of JSON records which can be read by Restore.
+func (c *Client) Backup(ctx context.Context, w io.Writer) (err error) {
+	c = c.WithContext(ctx)
+
+	gz := gzip.NewWriter(w)
+
+	defer func() {
+		if cerr := gz.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	enc := json.NewEncoder(gz)
+
+	if err := enc.Encode(backupHeader{Format: backupFormat, Version: backupVersion, CreatedAt: time.Now().UTC()}); err != nil {
+		return err
+	}
+
+	fields, err := c.CustomFields().List()
+
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		if err := enc.Encode(backupRecord{CustomField: field}); err != nil {
+			return err
+		}
+	}
+
+	lists, err := c.Lists().List()
+
+	if err != nil {
+		return err
+	}
+
+	memberships := make(map[string][]uint)
+
+	for _, list := range lists {
+		if err := enc.Encode(backupRecord{List: list}); err != nil {
+			return err
+		}
+
+		for recipient, err := range c.Lists().AllRecipients(list.ID) {
+			if err != nil {
+				return err
+			}
+
+			memberships[recipient.ID] = append(memberships[recipient.ID], list.ID)
+		}
+	}
+
+	segments, err := c.Segments().List()
+
+	if err != nil {
+		return err
+	}
+
+	for _, segment := range segments {
+		if err := enc.Encode(backupRecord{Segment: segment}); err != nil {
+			return err
+		}
+	}
+
+	for recipient, err := range c.Recipients().All() {
+		if err != nil {
+			return err
+		}
+
+		br := &backupRecipient{
+			ID:        recipient.ID,
+			Email:     recipient.Email,
+			FirstName: recipient.FirstName,
+			LastName:  recipient.LastName,
+			CreatedAt: recipient.CreatedAt,
+			ListIDs:   memberships[recipient.ID],
+		}
+
+		for _, f := range recipient.CustomFields {
+			if f.Value != nil {
+				br.CustomFields = append(br.CustomFields, backupCustomFieldValue{Name: f.Name, Value: f.Value})
+			}
+		}
+
+		if err := enc.Encode(backupRecord{Recipient: br}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RestoreResult summarises a Restore. IDs in the archive are mapped to the IDs of the objects they were
+// restored to, which differ between accounts.
+type RestoreResult struct {
+	CustomFieldIDs  map[uint]uint
+	ListIDs         map[uint]uint
+	SegmentIDs      map[uint]uint
+	Recipients      int
+	RecipientErrors int
+}
+
+// Restore recreates the contents of a Backup archive. Custom fields, lists and segments which already
+// exist with the same name are reused rather than recreated, so Restore can be used both to recover an
+// account and to copy one into another. Segment list IDs, and conditions on list membership or on custom
+// field IDs, are remapped to the restored IDs.
+func (c *Client) Restore(ctx context.Context, r io.Reader) (*RestoreResult, error) {
+	c = c.WithContext(ctx)
+
+	gz, err := gzip.NewReader(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer gz.Close()
+
+	dec := json.NewDecoder(bufio.NewReader(gz))
+
+	var header backupHeader
+
+	if err := dec.Decode(&header); err != nil {
+		return nil, err
+	}
+
+	if header.Format != backupFormat {
+		return nil, errors.New("contacts: not a contactdb backup")
+	}
+
+	if header.Version != backupVersion {
+		return nil, fmt.Errorf("contacts: unsupported backup version: %d", header.Version)
+	}
+
+	rs := &restore{
+		client: c,
+		result: &RestoreResult{
+			CustomFieldIDs: make(map[uint]uint),
+			ListIDs:        make(map[uint]uint),
+			SegmentIDs:     make(map[uint]uint),
+		},
+	}
+
+	if err := rs.loadExisting(); err != nil {
+		return nil, err
+	}
+
+	for {
+		var record backupRecord
+
+		err := dec.Decode(&record)
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return rs.result, err
+		}
+
+		switch {
+		case record.CustomField != nil:
+			err = rs.customField(record.CustomField)
+		case record.List != nil:
+			err = rs.list(record.List)
+		case record.Segment != nil:
+			err = rs.segment(record.Segment)
+		case record.Recipient != nil:
+			err = rs.recipient(record.Recipient)
+		}
+
+		if err != nil {
+			return rs.result, err
+		}
+	}
+
+	return rs.result, rs.flush()
+}
+
+type restore struct {
+	client *Client
+	result *RestoreResult
+
+	fields   map[string]*CustomField
+	lists    map[string]*List
+	segments map[string]*Segment
+
+	batch []*backupRecipient
+}
+
+func (rs *restore) loadExisting() error {
+	fields, err := rs.client.CustomFields().List()
+
+	if err != nil {
+		return err
+	}
+
+	lists, err := rs.client.Lists().List()
+
+	if err != nil {
+		return err
+	}
+
+	segments, err := rs.client.Segments().List()
+
+	if err != nil {
+		return err
+	}
+
+	rs.fields = make(map[string]*CustomField, len(fields))
+	rs.lists = make(map[string]*List, len(lists))
+	rs.segments = make(map[string]*Segment, len(segments))
+
+	for _, field := range fields {
+		rs.fields[field.Name] = field
+	}
+
+	for _, list := range lists {
+		rs.lists[list.Name] = list
+	}
+
+	for _, segment := range segments {
+		rs.segments[segment.Name] = segment
+	}
+
+	return nil
+}
+
+func (rs *restore) customField(field *CustomField) error {
+	existing, ok := rs.fields[field.Name]
+
+	if !ok {
+		existing = &CustomField{Name: field.Name, Type: field.Type}
+
+		if err := rs.client.CustomFields().Create(existing); err != nil {
+			return err
+		}
+
+		rs.fields[existing.Name] = existing
+	} else if existing.Type != field.Type {
+		return fmt.Errorf("contacts: custom field %q exists with type %s, backup has type %s", field.Name, existing.Type, field.Type)
+	}
+
+	rs.result.CustomFieldIDs[field.ID] = existing.ID
+
+	return nil
+}
+
+func (rs *restore) list(list *List) error {
+	existing, ok := rs.lists[list.Name]
+
+	if !ok {
+		var err error
+
+		existing, err = rs.client.Lists().Create(list.Name)
+
+		if err != nil {
+			return err
+		}
+
+		rs.lists[existing.Name] = existing
+	}
+
+	rs.result.ListIDs[list.ID] = existing.ID
+
+	return nil
+}
+
+func (rs *restore) segment(segment *Segment) error {
+	restored := &Segment{Name: segment.Name}
+
+	if segment.ListID != 0 {
+		listID, ok := rs.result.ListIDs[uint(segment.ListID)]
+
+		if !ok {
+			return fmt.Errorf("contacts: segment %q refers to list %d which is not in the backup", segment.Name, segment.ListID)
+		}
+
+		restored.ListID = int(listID)
+	}
+
+	for _, condition := range segment.Conditions {
+		restored.Conditions = append(restored.Conditions, rs.remapCondition(condition))
+	}
+
+	if existing, ok := rs.segments[segment.Name]; ok {
+		restored.ID = existing.ID
+
+		if err := rs.client.Segments().Update(restored); err != nil {
+			return err
+		}
+	} else if err := rs.client.Segments().Create(restored); err != nil {
+		return err
+	}
+
+	rs.segments[restored.Name] = restored
+	rs.result.SegmentIDs[segment.ID] = restored.ID
+
+	return nil
+}
+
+// remapCondition maps the list ID referred to by a Condition to its restored ID. Conditions refer to custom
+// fields by name, which is restored unchanged.
+func (rs *restore) remapCondition(condition Condition) Condition {
+	if condition.Field == "list_id" {
+		if id, err := strconv.ParseUint(condition.Value, 10, 0); err == nil {
+			if listID, ok := rs.result.ListIDs[uint(id)]; ok {
+				condition.Value = strconv.FormatUint(uint64(listID), 10)
+			}
+		}
+	}
+
+	return condition
+}
+
+func (rs *restore) recipient(recipient *backupRecipient) error {
+	rs.batch = append(rs.batch, recipient)
+
+	if len(rs.batch) == maxBatchSize {
+		return rs.flush()
+	}
+
+	return nil
+}
+
+// flush adds the batched recipients, then adds them to their restored lists.
+func (rs *restore) flush() error {
+	if len(rs.batch) == 0 {
+		return nil
+	}
+
+	recipients := make([]*Recipient, len(rs.batch))
+
+	for i, br := range rs.batch {
+		recipient := &Recipient{Email: br.Email, FirstName: br.FirstName, LastName: br.LastName}
+
+		for _, f := range br.CustomFields {
+			recipient.CustomFields = append(recipient.CustomFields, CustomField{Name: f.Name, Value: f.Value})
+		}
+
+		recipients[i] = recipient
+	}
+
+	resp, err := rs.client.Recipients().Add(recipients...)
+
+	if err != nil {
+		return err
+	}
+
+	rs.result.Recipients += len(recipients) - resp.ErrorCount
+	rs.result.RecipientErrors += resp.ErrorCount
+
+	members := make(map[uint][]string)
+
+	for i, br := range rs.batch {
+		if contains(resp.ErrorIndices, i) {
+			continue
+		}
+
+		for _, id := range br.ListIDs {
+			if listID, ok := rs.result.ListIDs[id]; ok {
+				members[listID] = append(members[listID], recipientID(recipients[i]))
+			}
+		}
+	}
+
+	for listID, ids := range members {
+		if err := rs.client.Lists().AddRecipientsByIDs(listID, ids...); err != nil {
+			return err
+		}
+	}
+
+	rs.batch = rs.batch[:0]
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package contacts
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestClient_BackupRestore(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	err := client.Backup(context.Background(), buf)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	result, err := client.Restore(context.Background(), buf)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	for from, to := range result.ListIDs {
+		if from != to {
+			t.Errorf("list %d restored to %d", from, to)
+		}
+	}
+}
+
+func TestRestore_remapCondition(t *testing.T) {
+	rs := &restore{result: &RestoreResult{
+		ListIDs:        map[uint]uint{1: 10},
+		CustomFieldIDs: map[uint]uint{2: 20},
+	}}
+
+	if c := rs.remapCondition(Condition{Field: "list_id", Value: "1", Operator: "eq"}); c.Value != "10" {
+		t.Fail()
+	}
+
+	// custom fields are referred to by name, even one which looks like a custom field ID
+	if c := rs.remapCondition(Condition{Field: "2", Value: "1", Operator: "eq"}); c.Field != "2" || c.Value != "1" {
+		t.Fail()
+	}
+
+	if c := rs.remapCondition(Condition{Field: "last_name", Value: "1", Operator: "eq"}); c.Field != "last_name" || c.Value != "1" {
+		t.Fail()
+	}
+}
+
+func TestClient_Backup_closesOnError(t *testing.T) {
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: &unauthorizedTransport{validKey: "other"}}
+
+	buf := new(bytes.Buffer)
+
+	if err := c.Backup(context.Background(), buf); err == nil {
+		t.FailNow()
+	}
+
+	zr, err := gzip.NewReader(buf)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	// the header written before the error is flushed and the stream terminated
+	if _, err := ioutil.ReadAll(zr); err != nil {
+		t.Error(err)
+	}
+}
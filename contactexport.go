@@ -0,0 +1,322 @@
+package contacts
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"iter"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Statuses of a ContactExport.
+const (
+	ExportPending = "pending"
+	ExportReady   = "ready"
+	ExportFailure = "failure"
+)
+
+// ContactExportOptions configure MarketingContactsClient.Export.
+type ContactExportOptions struct {
+	// ListIDs and SegmentIDs are the Lists and Segments to export. If both are empty, every Contact is
+	// exported.
+	ListIDs    []string
+	SegmentIDs []string
+
+	// Format is ExportCSV or ExportNDJSON, which exports JSON.
+	Format ExportFormat
+
+	// MaxFileSize is the largest size in megabytes of each exported file; larger exports are split.
+	MaxFileSize int
+}
+
+type contactExportRequest struct {
+	ListIDs     []string `json:"list_ids,omitempty"`
+	SegmentIDs  []string `json:"segment_ids,omitempty"`
+	FileType    string   `json:"file_type"`
+	MaxFileSize int      `json:"max_file_size,omitempty"`
+}
+
+type contactExportResponse struct {
+	ID string `json:"id"`
+}
+
+// ContactExport is an asynchronous export of Contacts in the new Marketing Campaigns API.
+type ContactExport struct {
+	ID     string
+	Format ExportFormat
+
+	// PollInterval and MaxPollInterval configure Wait, as for Job.
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+
+	client *Client
+}
+
+// ContactExportStatus is the status of a ContactExport. Once it is ready, URLs are the exported files.
+type ContactExportStatus struct {
+	ID          string     `json:"id"`
+	Status      string     `json:"status"`
+	URLs        []string   `json:"urls"`
+	Message     string     `json:"message"`
+	CreatedAt   *time.Time `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+// Export starts exporting Contacts. Use the returned ContactExport to wait for and read the export.
+//
+// https://docs.sendgrid.com/api-reference/contacts/export-contacts
+func (c *MarketingContactsClient) Export(ctx context.Context, opts *ContactExportOptions) (*ContactExport, error) {
+	if opts == nil {
+		opts = &ContactExportOptions{}
+	}
+
+	req := contactExportRequest{ListIDs: opts.ListIDs, SegmentIDs: opts.SegmentIDs, FileType: "csv", MaxFileSize: opts.MaxFileSize}
+
+	if opts.Format == ExportNDJSON {
+		req.FileType = "json"
+	}
+
+	var resp *contactExportResponse
+
+	err := c.client.makeRequest(ctx, "MarketingContactsClient.Export", http.MethodPost, "/marketing/contacts/exports", req, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ContactExport{
+		ID:              resp.ID,
+		Format:          opts.Format,
+		PollInterval:    defaultPollInterval,
+		MaxPollInterval: defaultMaxPollInterval,
+		client:          c.client,
+	}, nil
+}
+
+// Status gets the current status of the ContactExport.
+//
+// https://docs.sendgrid.com/api-reference/contacts/export-contacts-status
+func (e *ContactExport) Status(ctx context.Context) (*ContactExportStatus, error) {
+	var status *ContactExportStatus
+
+	err := e.client.makeRequest(ctx, "ContactExport.Status", http.MethodGet, "/marketing/contacts/exports/"+url.PathEscape(e.ID), nil, &status)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// Wait polls the ContactExport's status with exponential backoff until it is ready, it fails, or ctx is done.
+func (e *ContactExport) Wait(ctx context.Context) (*ContactExportStatus, error) {
+	var status *ContactExportStatus
+
+	err := poll(ctx, e.PollInterval, e.MaxPollInterval, func() (bool, error) {
+		var err error
+
+		status, err = e.Status(ctx)
+
+		return err == nil && status.Status != ExportPending, err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if status.Status == ExportFailure {
+		return status, fmt.Errorf("contacts: export %s failed: %s", e.ID, status.Message)
+	}
+
+	return status, nil
+}
+
+// Contacts waits for the ContactExport and then streams the Contacts in each of its files, downloading one
+// file at a time. Iteration stops at the first error. Custom fields in CSV exports are keyed by column
+// header, the field's name, rather than by field definition ID.
+func (e *ContactExport) Contacts(ctx context.Context) iter.Seq2[*Contact, error] {
+	return func(yield func(*Contact, error) bool) {
+		status, err := e.Wait(ctx)
+
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		for _, fileURL := range status.URLs {
+			err := e.readFile(ctx, fileURL, func(contact *Contact) error {
+				if !yield(contact, nil) {
+					return errStopIteration
+				}
+
+				return nil
+			})
+
+			if errors.Is(err, errStopIteration) {
+				return
+			} else if err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
+
+func (e *ContactExport) readFile(ctx context.Context, fileURL string, fn func(*Contact) error) error {
+	body, err := e.client.openDownload(ctx, fileURL)
+
+	if err != nil {
+		return err
+	}
+
+	defer body.Close()
+
+	if e.Format == ExportNDJSON {
+		return readJSONContacts(body, fn)
+	}
+
+	return readCSVContacts(body, fn)
+}
+
+// readJSONContacts reads a JSON export, which is either a stream of Contact objects or an array of them.
+// Arrays are decoded element by element, so a large export is never held in memory whole.
+func readJSONContacts(r io.Reader, fn func(*Contact) error) error {
+	dec := json.NewDecoder(r)
+
+	decodeContact := func(dec *json.Decoder) error {
+		var contact *Contact
+
+		if err := dec.Decode(&contact); err != nil {
+			return err
+		}
+
+		return fn(contact)
+	}
+
+	for dec.More() {
+		var err error
+
+		if peekJSON(dec) == '[' {
+			err = decodeArray(dec, decodeContact)
+		} else {
+			err = decodeContact(dec)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	// surface a stray closing delimiter, which also ends More
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("contacts: unexpected data in JSON export: %v", err)
+	}
+
+	return nil
+}
+
+// peekJSON returns the first byte of the next value of dec, once More has buffered it.
+func peekJSON(dec *json.Decoder) byte {
+	buffered, _ := ioutil.ReadAll(dec.Buffered())
+	buffered = bytes.TrimLeft(buffered, " \t\r\n")
+
+	if len(buffered) == 0 {
+		return 0
+	}
+
+	return buffered[0]
+}
+
+// contactColumns set the reserved fields of a Contact from the columns of a CSV export.
+var contactColumns = map[string]func(c *Contact, value string){
+	"contact_id":            func(c *Contact, v string) { c.ID = v },
+	"email":                 func(c *Contact, v string) { c.Email = v },
+	"first_name":            func(c *Contact, v string) { c.FirstName = v },
+	"last_name":             func(c *Contact, v string) { c.LastName = v },
+	"address_line_1":        func(c *Contact, v string) { c.AddressLine1 = v },
+	"address_line_2":        func(c *Contact, v string) { c.AddressLine2 = v },
+	"city":                  func(c *Contact, v string) { c.City = v },
+	"state_province_region": func(c *Contact, v string) { c.StateProvinceRegion = v },
+	"postal_code":           func(c *Contact, v string) { c.PostalCode = v },
+	"country":               func(c *Contact, v string) { c.Country = v },
+	"phone_number":          func(c *Contact, v string) { c.PhoneNumber = v },
+	"alternate_emails":      func(c *Contact, v string) { c.AlternateEmails = splitNonEmpty(v) },
+	"list_ids":              func(c *Contact, v string) { c.ListIDs = splitNonEmpty(v) },
+	"created_at":            func(c *Contact, v string) { c.CreatedAt = parseExportTime(v) },
+	"updated_at":            func(c *Contact, v string) { c.UpdatedAt = parseExportTime(v) },
+}
+
+func splitNonEmpty(v string) []string {
+	if v == "" {
+		return nil
+	}
+
+	return strings.Split(v, ",")
+}
+
+func parseExportTime(v string) *time.Time {
+	t, err := time.Parse(time.RFC3339, v)
+
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
+
+// readCSVContacts reads a CSV export, whose header row names the fields case insensitively.
+func readCSVContacts(r io.Reader, fn func(*Contact) error) error {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+
+	if err == io.EOF {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	header = append([]string(nil), header...)
+
+	for {
+		record, err := cr.Read()
+
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		contact := &Contact{}
+
+		for i, value := range record {
+			if i >= len(header) {
+				break
+			}
+
+			if set, ok := contactColumns[strings.ToLower(header[i])]; ok {
+				set(contact, value)
+			} else if value != "" {
+				if contact.CustomFields == nil {
+					contact.CustomFields = make(map[string]interface{})
+				}
+
+				contact.CustomFields[header[i]] = value
+			}
+		}
+
+		if err := fn(contact); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package contacts
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type exportTransport struct {
+	file []byte
+}
+
+func (x *exportTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	var body []byte
+
+	switch r.URL.Path {
+	case "/v3/marketing/contacts/exports/export-id":
+		body = []byte(`{"id": "export-id", "status": "ready", "urls": ["https://files.example.com/1.csv.gz", "https://files.example.com/2.csv.gz"]}`)
+	default:
+		body = x.file
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+func TestContactExport_Contacts(t *testing.T) {
+	var file bytes.Buffer
+
+	zw := gzip.NewWriter(&file)
+	zw.Write([]byte("EMAIL,FIRST_NAME,CONTACT_ID,LIST_IDS,pet\njohn@example.com,John,1,\"a,b\",dog\njane@example.com,Jane,2,,\n"))
+	zw.Close()
+
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: &exportTransport{file: file.Bytes()}}
+
+	export := &ContactExport{ID: "export-id", Format: ExportCSV, PollInterval: time.Millisecond, client: c}
+
+	var emails []string
+
+	for contact, err := range export.Contacts(context.Background()) {
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		emails = append(emails, contact.Email)
+
+		if contact.Email == "john@example.com" && (contact.FirstName != "John" || len(contact.ListIDs) != 2 || contact.CustomFields["pet"] != "dog") {
+			t.Error(contact)
+		}
+	}
+
+	if strings.Join(emails, ",") != "john@example.com,jane@example.com,john@example.com,jane@example.com" {
+		t.Error(emails)
+	}
+}
+
+func TestReadJSONContacts(t *testing.T) {
+	var emails []string
+
+	err := readJSONContacts(strings.NewReader(`{"email": "a@example.com"}
+[{"EMAIL": "b@example.com"}]`), func(contact *Contact) error {
+		emails = append(emails, contact.Email)
+		return nil
+	})
+
+	if err != nil || strings.Join(emails, ",") != "a@example.com,b@example.com" {
+		t.Error(err, emails)
+	}
+
+	// elements are handed over as they are decoded, before the rest of the array has been read
+	stop := errors.New("stop")
+
+	err = readJSONContacts(strings.NewReader(`[{"email": "c@example.com"}, {"email": `), func(contact *Contact) error {
+		return stop
+	})
+
+	if err != stop {
+		t.Error(err)
+	}
+
+	err = readJSONContacts(strings.NewReader(`{"email": "d@example.com"}]`), func(contact *Contact) error {
+		return nil
+	})
+
+	if err == nil {
+		t.Fail()
+	}
+}
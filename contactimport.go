@@ -0,0 +1,316 @@
+package contacts
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"iter"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContactImportOptions configure MarketingContactsClient.ImportCSV.
+type ContactImportOptions struct {
+	// ListIDs are the Lists to which every imported Contact is added.
+	ListIDs []string
+
+	// Columns maps CSV column headers to field names, reserved or custom. Columns which aren't mapped are
+	// matched to fields by their header, case insensitively. Columns mapped to "" are skipped.
+	Columns map[string]string
+}
+
+type contactImportRequest struct {
+	ListIDs       []string  `json:"list_ids,omitempty"`
+	FileType      string    `json:"file_type"`
+	FieldMappings []*string `json:"field_mappings"`
+}
+
+type contactImportResponse struct {
+	JobID         string `json:"job_id"`
+	UploadURI     string `json:"upload_uri"`
+	UploadHeaders []struct {
+		Header string `json:"header"`
+		Value  string `json:"value"`
+	} `json:"upload_headers"`
+}
+
+// ImportCSV imports Contacts from a CSV file with a header row, by uploading it gzipped to the pre-signed
+// URL SendGrid provides, which avoids the size limits of Upsert. Each column is mapped to the ID of a field
+// definition; a column which matches no field is an error. The file is compressed to a temporary file
+// before it is uploaded, as the upload needs its length. The import happens asynchronously; use the
+// returned Job to wait for it.
+//
+// https://docs.sendgrid.com/api-reference/contacts/import-contacts
+func (c *MarketingContactsClient) ImportCSV(ctx context.Context, r io.Reader, opts *ContactImportOptions) (*Job, error) {
+	if opts == nil {
+		opts = &ContactImportOptions{}
+	}
+
+	br := bufio.NewReader(r)
+
+	// spreadsheets often save CSV with a byte order mark, which would otherwise be part of the first header
+	if bom, _ := br.Peek(3); string(bom) == "\ufeff" {
+		br.Discard(3)
+	}
+
+	// csv.NewReader reads from br itself rather than buffering it again, so once the header has been read
+	// br is positioned at the first record, even if a quoted header spans lines
+	header, err := csv.NewReader(br).Read()
+
+	if err != nil {
+		return nil, fmt.Errorf("contacts: reading CSV header: %w", err)
+	}
+
+	var headerLine bytes.Buffer
+
+	w := csv.NewWriter(&headerLine)
+	w.Write(header)
+	w.Flush()
+
+	mappings, err := c.fieldMappings(ctx, header, opts.Columns)
+
+	if err != nil {
+		return nil, err
+	}
+
+	file, size, err := gzipToTemp(io.MultiReader(&headerLine, br))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	var resp *contactImportResponse
+
+	err = c.client.makeRequest(ctx, "MarketingContactsClient.ImportCSV", http.MethodPut, "/marketing/contacts/imports", contactImportRequest{ListIDs: opts.ListIDs, FileType: "csv", FieldMappings: mappings}, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, resp.UploadURI, file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.ContentLength = size
+	req.Header.Set("Content-Encoding", "gzip")
+
+	for _, h := range resp.UploadHeaders {
+		req.Header.Set(h.Header, h.Value)
+	}
+
+	upload, err := c.client.HTTPClient.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer upload.Body.Close()
+
+	if upload.StatusCode >= http.StatusBadRequest {
+		b, _ := ioutil.ReadAll(upload.Body)
+
+		return nil, &APIError{StatusCode: upload.StatusCode, Body: string(b)}
+	}
+
+	return newJob(c.client, resp.JobID), nil
+}
+
+// fieldMappings maps each column header to the ID of a field definition, or nil if it is skipped.
+func (c *MarketingContactsClient) fieldMappings(ctx context.Context, header []string, columns map[string]string) ([]*string, error) {
+	custom, reserved, err := c.client.FieldDefinitions().List(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]string, len(custom)+len(reserved))
+
+	for _, field := range append(reserved, custom...) {
+		ids[strings.ToLower(field.Name)] = field.ID
+	}
+
+	mappings := make([]*string, len(header))
+	hasEmail := false
+
+	for i, column := range header {
+		name, ok := columns[column]
+
+		if !ok {
+			name = column
+		}
+
+		if name == "" {
+			continue
+		}
+
+		id, ok := ids[strings.ToLower(name)]
+
+		if !ok {
+			return nil, fmt.Errorf("contacts: CSV column %q matches no field", column)
+		}
+
+		hasEmail = hasEmail || strings.EqualFold(name, "email")
+		mappings[i] = &id
+	}
+
+	if !hasEmail {
+		return nil, fmt.Errorf("contacts: CSV has no email column")
+	}
+
+	return mappings, nil
+}
+
+// gzipToTemp compresses r to a temporary file, returning it rewound along with its size.
+func gzipToTemp(r io.Reader) (*os.File, int64, error) {
+	file, err := os.CreateTemp("", "contacts-import-*.csv.gz")
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	zw := gzip.NewWriter(file)
+
+	_, err = io.Copy(zw, r)
+
+	if err == nil {
+		err = zw.Close()
+	}
+
+	var size int64
+
+	if err == nil {
+		size, err = file.Seek(0, io.SeekCurrent)
+	}
+
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+
+		return nil, 0, err
+	}
+
+	return file, size, nil
+}
+
+// ImportRecipients imports legacy Recipients as Contacts with ImportCSV, converting them to a CSV file with
+// a column for email, first_name, last_name and each custom field, which must exist as field definitions.
+// If there are no Recipients, nothing is imported and the Job is nil.
+func (c *MarketingContactsClient) ImportRecipients(ctx context.Context, recipients []*Recipient, listIDs ...string) (*Job, error) {
+	names := make(map[string]bool)
+
+	for _, recipient := range recipients {
+		for _, field := range recipient.CustomFields {
+			names[field.Name] = true
+		}
+	}
+
+	return c.importRecipients(ctx, sortedIDs(names), func(yield func(*Recipient, error) bool) {
+		for _, recipient := range recipients {
+			if !yield(recipient, nil) {
+				return
+			}
+		}
+	}, listIDs...)
+}
+
+// importRecipients is ImportRecipients for a sequence of Recipients, which are streamed to the upload
+// rather than held in memory, so the custom field columns must be known in advance.
+func (c *MarketingContactsClient) importRecipients(ctx context.Context, fieldNames []string, recipients iter.Seq2[*Recipient, error], listIDs ...string) (*Job, error) {
+	next, stop := iter.Pull2(recipients)
+
+	first, err, ok := next()
+
+	if !ok || err != nil {
+		stop()
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+
+	go func() {
+		defer stop()
+
+		pw.CloseWithError(writeRecipientsCSV(pw, fieldNames, first, next))
+	}()
+
+	defer pr.Close()
+
+	return c.ImportCSV(ctx, pr, &ContactImportOptions{ListIDs: listIDs})
+}
+
+// writeRecipientsCSV writes first and then each Recipient returned by next as a CSV row.
+func writeRecipientsCSV(w io.Writer, fieldNames []string, first *Recipient, next func() (*Recipient, error, bool)) error {
+	header := []string{"email", "first_name", "last_name"}
+	header = append(header, fieldNames...)
+
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(header))
+
+	for recipient, err, ok := first, error(nil), true; ok; recipient, err, ok = next() {
+		if err != nil {
+			return err
+		}
+
+		record[0], record[1], record[2] = recipient.Email, recipient.FirstName, recipient.LastName
+
+		for i, name := range fieldNames {
+			record[3+i] = ""
+
+			for _, field := range recipient.CustomFields {
+				if field.Name == name {
+					record[3+i] = recipientCSVValue(field)
+				}
+			}
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// recipientCSVValue formats a legacy custom field value for the new API, which reads dates as MM/DD/YYYY
+// rather than unix timestamps.
+func recipientCSVValue(field CustomField) string {
+	if field.Value == nil {
+		return ""
+	}
+
+	if field.Type == FieldTypeDate {
+		if ts, ok := numericValue(field.Value); ok {
+			return time.Unix(int64(ts), 0).UTC().Format("01/02/2006")
+		}
+	}
+
+	if f, ok := field.Value.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	return fmt.Sprint(field.Value)
+}
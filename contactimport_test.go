@@ -0,0 +1,159 @@
+package contacts
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type importTransport struct {
+	mappings []*string
+	uploaded string
+	header   http.Header
+}
+
+func (x *importTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	body := `{}`
+
+	switch {
+	case r.URL.Path == "/v3/marketing/field_definitions":
+		body = `{"custom_fields": [{"id": "e1_T", "name": "pet", "field_type": "Text"}], "reserved_fields": [{"id": "_rf0_T", "name": "first_name", "field_type": "Text"}, {"id": "_rf1_T", "name": "email", "field_type": "Text"}, {"id": "_rf2_T", "name": "last_name", "field_type": "Text"}]}`
+	case r.URL.Path == "/v3/marketing/contacts/imports":
+		var req contactImportRequest
+
+		json.NewDecoder(r.Body).Decode(&req)
+		x.mappings = req.FieldMappings
+
+		body = `{"job_id": "job-id", "upload_uri": "https://upload.example.com/file", "upload_headers": [{"header": "x-amz-server-side-encryption", "value": "aws:kms"}]}`
+	case r.URL.Host == "upload.example.com":
+		zr, err := gzip.NewReader(r.Body)
+
+		if err != nil {
+			return nil, err
+		}
+
+		b, _ := ioutil.ReadAll(zr)
+		x.uploaded, x.header = string(b), r.Header
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+func TestMarketingContactsClient_ImportRecipients(t *testing.T) {
+	transport := &importTransport{}
+
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: transport}
+
+	job, err := c.MarketingContacts().ImportRecipients(context.Background(), []*Recipient{
+		{Email: "john@example.com", FirstName: "John", CustomFields: []CustomField{{Name: "pet", Value: "dog"}}},
+	})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if job.ID != "job-id" {
+		t.Fail()
+	}
+
+	var mappings []string
+
+	for _, m := range transport.mappings {
+		mappings = append(mappings, *m)
+	}
+
+	if strings.Join(mappings, ",") != "_rf1_T,_rf0_T,_rf2_T,e1_T" {
+		t.Error(mappings)
+	}
+
+	if transport.uploaded != "email,first_name,last_name,pet\njohn@example.com,John,,dog\n" {
+		t.Error(transport.uploaded)
+	}
+
+	if transport.header.Get("x-amz-server-side-encryption") != "aws:kms" || transport.header.Get("Content-Encoding") != "gzip" {
+		t.Error(transport.header)
+	}
+}
+
+func TestMarketingContactsClient_importRecipients(t *testing.T) {
+	transport := &importTransport{}
+
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: transport}
+
+	job, err := c.MarketingContacts().importRecipients(context.Background(), []string{"pet"}, func(yield func(*Recipient, error) bool) {})
+
+	if err != nil || job != nil || transport.uploaded != "" {
+		t.Error(job, err)
+	}
+
+	_, err = c.MarketingContacts().importRecipients(context.Background(), []string{"pet"}, func(yield func(*Recipient, error) bool) {
+		if yield(&Recipient{Email: "john@example.com"}, nil) {
+			yield(nil, errors.New("page failed"))
+		}
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "page failed") {
+		t.Error(err)
+	}
+}
+
+func TestMarketingContactsClient_ImportCSV_unknownColumn(t *testing.T) {
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: &importTransport{}}
+
+	_, err := c.MarketingContacts().ImportCSV(context.Background(), bytes.NewBufferString("email,shoe_size\na@example.com,9\n"), nil)
+
+	if err == nil || !strings.Contains(err.Error(), "shoe_size") {
+		t.Error(err)
+	}
+}
+
+func TestMarketingContactsClient_ImportCSV_header(t *testing.T) {
+	tests := []struct {
+		name     string
+		csv      string
+		uploaded string
+	}{
+		{"bom", "\ufeffemail,pet\na@example.com,dog\n", "email,pet\na@example.com,dog\n"},
+		{"quoted", "\"email\",\"pet\nname\"\na@example.com,\"dog\"\n", "email,\"pet\nname\"\na@example.com,\"dog\"\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transport := &importTransport{}
+
+			c := New("apikey")
+			c.HTTPClient = &http.Client{Transport: transport}
+
+			_, err := c.MarketingContacts().ImportCSV(context.Background(), strings.NewReader(test.csv), &ContactImportOptions{
+				Columns: map[string]string{"pet\nname": "pet"},
+			})
+
+			if err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+
+			if len(transport.mappings) != 2 || *transport.mappings[0] != "_rf1_T" || *transport.mappings[1] != "e1_T" {
+				t.Error(transport.mappings)
+			}
+
+			if transport.uploaded != test.uploaded {
+				t.Errorf("%q", transport.uploaded)
+			}
+		})
+	}
+}
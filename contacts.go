@@ -1,55 +1,137 @@
 package contacts
 
 import (
-	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const sendgridAPIv3Base = "https://api.sendgrid.com/v3"
 
-func New(apikey string) *Client {
+// ErrMissingAPIKey is returned when no API key is available for a request.
+var ErrMissingAPIKey = errors.New("contacts: apikey must be set")
+
+// NewClient creates a Client which authenticates with a fixed apikey, returning ErrMissingAPIKey if apikey is
+// empty. Use NewWithCredentials to rotate keys.
+func NewClient(apikey string) (*Client, error) {
 	if apikey == "" {
-		panic(errors.New("contacts: apikey must be set"))
+		return nil, ErrMissingAPIKey
 	}
 
 	return &Client{
 		APIKey:     apikey,
 		HTTPClient: http.DefaultClient,
+	}, nil
+}
+
+// New is like NewClient, but panics if apikey is empty.
+func New(apikey string) *Client {
+	c, err := NewClient(apikey)
+
+	if err != nil {
+		panic(err)
 	}
+
+	return c
+}
+
+// NewWithCredentials creates a Client which asks credentials for the API key before every request. Static
+// credentials are checked immediately, returning ErrMissingAPIKey if they are empty.
+func NewWithCredentials(credentials CredentialProvider) (*Client, error) {
+	if credentials == nil {
+		return nil, errors.New("contacts: credentials must be set")
+	}
+
+	if static, ok := credentials.(StaticCredentials); ok && static == "" {
+		return nil, ErrMissingAPIKey
+	}
+
+	return &Client{
+		Credentials: credentials,
+		HTTPClient:  http.DefaultClient,
+	}, nil
 }
 
 type Client struct {
+	// APIKey is used to authenticate requests when Credentials is nil.
 	APIKey     string
 	HTTPClient *http.Client
-}
 
-func (c *Client) makeRequest(method, url string, data, output interface{}) error {
-	var body io.Reader
+	// Credentials, if set, is consulted for the API key before every request, and refreshed
+	// if SendGrid rejects the key.
+	Credentials CredentialProvider
 
-	if method != http.MethodGet && data != nil {
-		var err error
-		body, err = c.marshal(data)
+	// TracerProvider, if set, is used to create a span for every operation made by the Client.
+	TracerProvider trace.TracerProvider
 
-		if err != nil {
-			return err
-		}
+	// MeterProvider, if set, is used to record request duration and error count histograms.
+	MeterProvider metric.MeterProvider
+
+	// CompressRequests gzips request bodies, setting the Content-Encoding header.
+	CompressRequests bool
+
+	ctx        context.Context
+	onBehalfOf string
+	metrics    *clientMetrics
+}
+
+// WithContext returns a shallow copy of the Client which makes its requests with ctx. Spans created for
+// those requests are children of any span in ctx.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic(errors.New("contacts: nil context"))
 	}
 
-	req, err := http.NewRequest(method, sendgridAPIv3Base+url, body)
+	c.sharedMetrics()
 
-	if err != nil {
-		return err
+	c2 := *c
+	c2.ctx = ctx
+
+	return &c2
+}
+
+// OnBehalfOf returns a shallow copy of the Client which makes all of its requests on behalf of the given subuser,
+// using the parent account's API key. The original Client is unaffected, so scoped clients for different
+// subusers can be used concurrently.
+//
+// https://sendgrid.com/docs/User_Guide/Settings/subusers.html
+func (c *Client) OnBehalfOf(subuser string) *Client {
+	c.sharedMetrics()
+
+	c2 := *c
+	c2.onBehalfOf = subuser
+
+	return &c2
+}
+
+func (c *Client) context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
 	}
 
-	req.Header.Add("Authorization", "Bearer "+c.APIKey)
-	req.Header.Add("Content-Type", "application/json")
+	return context.Background()
+}
+
+func (c *Client) makeRequest(ctx context.Context, operation, method, url string, data, output interface{}) (err error) {
+	ctx, span := c.startSpan(ctx, operation, method, data)
+
+	start := time.Now()
+	statusCode := 0
 
-	resp, err := c.HTTPClient.Do(req)
+	defer func() {
+		c.endSpan(ctx, span, operation, start, statusCode, output, err)
+	}()
+
+	resp, err := c.send(ctx, method, url, data)
 
 	if err != nil {
 		return err
@@ -57,35 +139,225 @@ func (c *Client) makeRequest(method, url string, data, output interface{}) error
 
 	defer resp.Body.Close()
 
-	if output != nil && resp.StatusCode < http.StatusBadRequest {
+	statusCode = resp.StatusCode
+
+	if output != nil && resp.StatusCode < http.StatusBadRequest && resp.StatusCode != http.StatusNoContent {
 		err = c.unmarshal(resp.Body, output)
 
 		return err
 	} else if resp.StatusCode >= http.StatusBadRequest {
-		b, err := ioutil.ReadAll(resp.Body)
+		b, _ := ioutil.ReadAll(resp.Body)
 
-		if err != nil {
-			return fmt.Errorf("contacts: bad status code observed: %d", resp.StatusCode)
-		} else {
-			return fmt.Errorf("contacts: bad status code observed: %d, body: %s", resp.StatusCode, string(b))
-		}
+		return &APIError{StatusCode: resp.StatusCode, Body: string(b)}
 	}
 
 	return nil
 }
 
-func (c *Client) marshal(data interface{}) (io.Reader, error) {
-	buf := new(bytes.Buffer)
+// APIError is returned when SendGrid responds with an error status code.
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("contacts: bad status code observed: %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("contacts: bad status code observed: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// IsNotFound reports whether err is an APIError with status 404 Not Found.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// send makes a request with the current API key. If SendGrid rejects the key, the credentials are refreshed
+// and the request is retried once with the new key.
+func (c *Client) send(ctx context.Context, method, url string, data interface{}) (*http.Response, error) {
+	credentials := c.credentials()
+
+	apiKey, err := credentials.APIKey(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendWithKey(ctx, method, url, data, apiKey)
+
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	refresher, ok := credentials.(RefreshingCredentialProvider)
+
+	if !ok {
+		return resp, nil
+	}
+
+	if err := refresher.Refresh(ctx); err != nil {
+		return resp, nil
+	}
+
+	newKey, err := credentials.APIKey(ctx)
+
+	if err != nil || newKey == apiKey {
+		return resp, nil
+	}
+
+	resp.Body.Close()
+
+	return c.sendWithKey(ctx, method, url, data, newKey)
+}
+
+func (c *Client) sendWithKey(ctx context.Context, method, url string, data interface{}, apiKey string) (*http.Response, error) {
+	var body io.ReadCloser
+
+	if method != http.MethodGet && data != nil {
+		body = c.marshal(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, sendgridAPIv3Base+url, body)
+
+	if err != nil {
+		if body != nil {
+			body.Close()
+		}
 
-	if err := json.NewEncoder(buf).Encode(data); err != nil {
 		return nil, err
 	}
 
-	return buf, nil
+	req.Header.Add("Authorization", "Bearer "+apiKey)
+	req.Header.Add("Content-Type", "application/json")
+
+	if body != nil && c.CompressRequests {
+		req.Header.Add("Content-Encoding", "gzip")
+	}
+
+	if c.onBehalfOf != "" {
+		req.Header.Add("On-Behalf-Of", c.onBehalfOf)
+	}
+
+	return c.HTTPClient.Do(req)
+}
+
+// marshal streams the JSON encoding of data, compressed if CompressRequests is set, so that large
+// request bodies are never held in memory in full. Encoding errors are returned from Read.
+func (c *Client) marshal(data interface{}) io.ReadCloser {
+	pr, pw := io.Pipe()
+
+	go func() {
+		var w io.Writer = pw
+		var gz *gzip.Writer
+
+		if c.CompressRequests {
+			gz = gzip.NewWriter(pw)
+			w = gz
+		}
+
+		err := json.NewEncoder(w).Encode(data)
+
+		if err == nil && gz != nil {
+			err = gz.Close()
+		}
+
+		pw.CloseWithError(err)
+	}()
+
+	return pr
+}
+
+// streamDecoder is implemented by responses which decode themselves incrementally, rather than
+// decoding the whole response body at once.
+type streamDecoder interface {
+	decodeStream(dec *json.Decoder) error
 }
 
 func (c *Client) unmarshal(r io.Reader, into interface{}) error {
-	return json.NewDecoder(r).Decode(into)
+	dec := json.NewDecoder(r)
+
+	if sd, ok := into.(streamDecoder); ok {
+		return sd.decodeStream(dec)
+	}
+
+	return dec.Decode(into)
+}
+
+// decodeArray decodes each element of the JSON array at the decoder's position, passing it to fn.
+func decodeArray(dec *json.Decoder, fn func(dec *json.Decoder) error) error {
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		if err := fn(dec); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, ']')
+}
+
+// decodeObject calls fn with the key of each member of the JSON object at the decoder's position. fn must
+// decode the member's value. A JSON null is treated as an empty object.
+func decodeObject(dec *json.Decoder, fn func(dec *json.Decoder, key string) error) error {
+	tok, err := dec.Token()
+
+	if err != nil {
+		return err
+	}
+
+	if tok == nil {
+		return nil
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("contacts: expected object, got %v", tok)
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+
+		if err != nil {
+			return err
+		}
+
+		key, ok := tok.(string)
+
+		if !ok {
+			return fmt.Errorf("contacts: expected object key, got %v", tok)
+		}
+
+		if err := fn(dec, key); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+
+	if err != nil {
+		return err
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("contacts: expected %v, got %v", want, tok)
+	}
+
+	return nil
+}
+
+// skipValue discards the JSON value at the decoder's position.
+func skipValue(dec *json.Decoder) error {
+	var v json.RawMessage
+
+	return dec.Decode(&v)
 }
 
 func (c *Client) Recipients() *RecipientClient {
@@ -103,3 +375,19 @@ func (c *Client) Segments() *SegmentsClient {
 func (c *Client) CustomFields() *CustomFieldsClient {
 	return &CustomFieldsClient{client: c}
 }
+
+func (c *Client) MarketingContacts() *MarketingContactsClient {
+	return &MarketingContactsClient{client: c}
+}
+
+func (c *Client) FieldDefinitions() *FieldDefinitionsClient {
+	return &FieldDefinitionsClient{client: c}
+}
+
+func (c *Client) MarketingLists() *MarketingListsClient {
+	return &MarketingListsClient{client: c}
+}
+
+func (c *Client) SegmentsV2() *SegmentsV2Client {
+	return &SegmentsV2Client{client: c}
+}
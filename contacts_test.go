@@ -1,9 +1,15 @@
 package contacts
 
 import (
+	"compress/gzip"
+	"encoding/json"
 	"go.uber.org/ratelimit"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
+	"testing"
 )
 
 var client *Client
@@ -24,3 +30,74 @@ func (x *RateLimitingTransport) RoundTrip(r *http.Request) (*http.Response, erro
 
 	return http.DefaultTransport.RoundTrip(r)
 }
+
+type headerRecordingTransport struct {
+	header http.Header
+	url    *url.URL
+}
+
+func (x *headerRecordingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	x.header = r.Header
+	x.url = r.URL
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(`{"lists": []}`)),
+		Request:    r,
+	}, nil
+}
+
+func TestClient_OnBehalfOf(t *testing.T) {
+	transport := &headerRecordingTransport{}
+
+	parent := New("apikey")
+	parent.HTTPClient = &http.Client{Transport: transport}
+
+	_, err := parent.OnBehalfOf("subuser").Lists().List()
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if transport.header.Get("On-Behalf-Of") != "subuser" {
+		t.Fail()
+	}
+
+	_, err = parent.Lists().List()
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if transport.header.Get("On-Behalf-Of") != "" {
+		t.Fail()
+	}
+}
+
+func TestClient_marshal(t *testing.T) {
+	c := New("apikey")
+	c.CompressRequests = true
+
+	body := c.marshal([]string{"a", "b"})
+	defer body.Close()
+
+	gz, err := gzip.NewReader(body)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	var got []string
+
+	if err := json.NewDecoder(gz).Decode(&got); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Fail()
+	}
+}
@@ -0,0 +1,781 @@
+package contacts
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ContactStore is the contact operations common to the legacy contactdb and the new Marketing Campaigns
+// API, so that code can switch between them without changing. In a ContactStore, Contacts are identified
+// by email address, List IDs are strings, and Contact.CustomFields are keyed by field name on both
+// backends. Fields left empty in an upserted Contact are not changed.
+type ContactStore interface {
+	// Upsert adds or updates Contacts, matched by email address, returning once they are stored.
+	Upsert(ctx context.Context, contacts ...*Contact) error
+
+	// GetByEmail gets a Contact and the IDs of its Lists. If there is none, IsNotFound reports true for the
+	// error.
+	GetByEmail(ctx context.Context, email string) (*Contact, error)
+
+	// Delete Contacts by email address, skipping any which don't exist. The Marketing backend deletes
+	// asynchronously, so deleted Contacts may still be returned for a while afterwards.
+	Delete(ctx context.Context, emails ...string) error
+
+	// AddToList adds existing Contacts to a List, returning an error without changing the List if any of
+	// them don't exist. RemoveFromList removes them without deleting them, skipping any which don't exist;
+	// like Delete, the Marketing backend removes them asynchronously.
+	AddToList(ctx context.Context, listID string, emails ...string) error
+	RemoveFromList(ctx context.Context, listID string, emails ...string) error
+
+	// All iterates over every Contact. Iteration stops at the first error.
+	All(ctx context.Context) iter.Seq2[*Contact, error]
+
+	// Search for Contacts matching an SGQL Query. The Marketing backend returns at most 50 matches; the
+	// legacy backend evaluates the Query locally over every Contact.
+	Search(ctx context.Context, query Query) ([]*Contact, error)
+}
+
+// StoreBackend selects the implementation of a ContactStore, e.g. from configuration.
+type StoreBackend string
+
+// Backends of a ContactStore.
+const (
+	// StoreLegacy stores Recipients in the legacy contactdb. Only the email address, names and custom
+	// fields of a Contact are stored. Contacts from All have no ListIDs, as the legacy API can only list
+	// them per Recipient.
+	StoreLegacy StoreBackend = "legacy"
+	// StoreMarketing stores Contacts in the new Marketing Campaigns API.
+	StoreMarketing StoreBackend = "marketing"
+)
+
+// ContactStore returns a ContactStore over the given backend.
+func (c *Client) ContactStore(backend StoreBackend) (ContactStore, error) {
+	switch backend {
+	case StoreLegacy:
+		return &legacyStore{client: c}, nil
+	case StoreMarketing:
+		return &marketingStore{client: c}, nil
+	default:
+		return nil, fmt.Errorf("contacts: unknown contact store backend %q", backend)
+	}
+}
+
+type legacyStore struct {
+	client *Client
+}
+
+func (s *legacyStore) Upsert(ctx context.Context, contacts ...*Contact) error {
+	recipients := s.client.WithContext(ctx).Recipients()
+
+	for start := 0; start < len(contacts); start += maxBatchSize {
+		batch := contacts[start:min(start+maxBatchSize, len(contacts))]
+		fields := make([]map[string]interface{}, len(batch))
+
+		for i, contact := range batch {
+			recipient := map[string]interface{}{"email": contact.Email}
+
+			if contact.FirstName != "" {
+				recipient["first_name"] = contact.FirstName
+			}
+
+			if contact.LastName != "" {
+				recipient["last_name"] = contact.LastName
+			}
+
+			for name, value := range contact.CustomFields {
+				recipient[name] = value
+			}
+
+			fields[i] = recipient
+		}
+
+		resp, err := recipients.updateFields(fields)
+
+		if err != nil {
+			return err
+		}
+
+		if resp.ErrorCount > 0 {
+			message := ""
+
+			if len(resp.Errors) > 0 {
+				message = ": " + resp.Errors[0].Message
+			}
+
+			return fmt.Errorf("contacts: %d of %d contacts were not upserted%s", resp.ErrorCount, len(batch), message)
+		}
+	}
+
+	return nil
+}
+
+func (s *legacyStore) GetByEmail(ctx context.Context, email string) (*Contact, error) {
+	c := s.client.WithContext(ctx)
+
+	recipient, err := c.findRecipient(strings.ToLower(strings.TrimSpace(email)))
+
+	if err != nil {
+		return nil, err
+	}
+
+	lists, err := c.Recipients().ListsForRecipient(recipient.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	contact := recipientContact(recipient)
+
+	for _, list := range lists {
+		contact.ListIDs = append(contact.ListIDs, strconv.FormatUint(uint64(list.ID), 10))
+	}
+
+	return contact, nil
+}
+
+func (s *legacyStore) Delete(ctx context.Context, emails ...string) error {
+	return s.client.WithContext(ctx).Recipients().Delete(emailRecipientIDs(emails))
+}
+
+func (s *legacyStore) AddToList(ctx context.Context, listID string, emails ...string) error {
+	id, err := strconv.ParseUint(listID, 10, 0)
+
+	if err != nil {
+		return fmt.Errorf("contacts: invalid legacy list ID %q", listID)
+	}
+
+	c := s.client.WithContext(ctx)
+
+	// each Recipient is looked up first, so the List is left unchanged if any of them don't exist
+	ids := make([]string, 0, len(emails))
+
+	var missing []string
+
+	for _, email := range emails {
+		recipient, err := c.findRecipient(strings.ToLower(strings.TrimSpace(email)))
+
+		if IsNotFound(err) {
+			missing = append(missing, email)
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		ids = append(ids, recipient.ID)
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("contacts: no contacts with email addresses %s", strings.Join(missing, ", "))
+	}
+
+	return c.Lists().AddRecipientsByIDs(uint(id), ids...)
+}
+
+func (s *legacyStore) RemoveFromList(ctx context.Context, listID string, emails ...string) error {
+	id, err := strconv.ParseUint(listID, 10, 0)
+
+	if err != nil {
+		return fmt.Errorf("contacts: invalid legacy list ID %q", listID)
+	}
+
+	_, err = s.client.WithContext(ctx).Lists().DeleteRecipients(uint(id), emailRecipientIDs(emails)...)
+
+	return err
+}
+
+func (s *legacyStore) All(ctx context.Context) iter.Seq2[*Contact, error] {
+	return func(yield func(*Contact, error) bool) {
+		for recipient, err := range s.client.WithContext(ctx).Recipients().All() {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if !yield(recipientContact(recipient), nil) {
+				return
+			}
+		}
+	}
+}
+
+// Search evaluates the Query over every Recipient. If it uses list_ids, e.g. with InList, each List is paged
+// through first to find the Lists of every Recipient.
+func (s *legacyStore) Search(ctx context.Context, query Query) ([]*Contact, error) {
+	if _, err := BuildSGQL(query); err != nil {
+		return nil, err
+	}
+
+	var listIDs map[string][]string
+
+	if usesField(query, "list_ids") {
+		var err error
+
+		listIDs, err = s.listIDs(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	likes := likePatterns(query, make(map[string]*regexp.Regexp))
+
+	var matches []*Contact
+
+	for contact, err := range s.All(ctx) {
+		if err != nil {
+			return nil, err
+		}
+
+		contact.ListIDs = listIDs[contact.ID]
+
+		if evalQuery(query, contact, likes) {
+			matches = append(matches, contact)
+		}
+	}
+
+	return matches, nil
+}
+
+// listIDs gets the IDs of the Lists of every Recipient on a List, keyed by recipient ID.
+func (s *legacyStore) listIDs(ctx context.Context) (map[string][]string, error) {
+	lists := s.client.WithContext(ctx).Lists()
+
+	all, err := lists.List()
+
+	if err != nil {
+		return nil, err
+	}
+
+	listIDs := make(map[string][]string)
+
+	for _, list := range all {
+		id := strconv.FormatUint(uint64(list.ID), 10)
+
+		for recipient, err := range lists.AllRecipients(list.ID) {
+			if err != nil {
+				return nil, err
+			}
+
+			listIDs[recipient.ID] = append(listIDs[recipient.ID], id)
+		}
+	}
+
+	return listIDs, nil
+}
+
+func emailRecipientIDs(emails []string) []string {
+	ids := make([]string, len(emails))
+
+	for i, email := range emails {
+		ids[i] = recipientID(&Recipient{Email: email})
+	}
+
+	return ids
+}
+
+func recipientContact(r *Recipient) *Contact {
+	contact := &Contact{ID: r.ID, Email: r.Email, FirstName: r.FirstName, LastName: r.LastName}
+
+	if r.CreatedAt != 0 {
+		createdAt := time.Unix(int64(r.CreatedAt), 0).UTC()
+		contact.CreatedAt = &createdAt
+	}
+
+	for _, field := range r.CustomFields {
+		if field.Value == nil {
+			continue
+		}
+
+		if contact.CustomFields == nil {
+			contact.CustomFields = make(map[string]interface{})
+		}
+
+		contact.CustomFields[field.Name] = field.Value
+	}
+
+	return contact
+}
+
+type marketingStore struct {
+	client *Client
+
+	mu       sync.Mutex
+	fieldIDs map[string]string
+	names    map[string]string
+}
+
+// fields gets the maps from custom field names to IDs and from IDs to names, by which the new API keys
+// Contact.CustomFields when writing. They are loaded on first use, and reloaded if any of keys is neither a
+// known name nor ID, as the field may have been created since.
+func (s *marketingStore) fields(ctx context.Context, keys ...string) (fieldIDs, names map[string]string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fieldIDs != nil && s.known(keys) {
+		return s.fieldIDs, s.names, nil
+	}
+
+	custom, _, err := s.client.FieldDefinitions().List(ctx)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.fieldIDs, s.names = make(map[string]string, len(custom)), make(map[string]string, len(custom))
+
+	for _, field := range custom {
+		s.fieldIDs[field.Name], s.names[field.ID] = field.ID, field.Name
+	}
+
+	return s.fieldIDs, s.names, nil
+}
+
+func (s *marketingStore) known(keys []string) bool {
+	for _, key := range keys {
+		_, name := s.fieldIDs[key]
+		_, id := s.names[key]
+
+		if !name && !id {
+			return false
+		}
+	}
+
+	return true
+}
+
+// customFieldKeys gets the keys of the custom fields of Contacts.
+func customFieldKeys(contacts ...*Contact) []string {
+	var keys []string
+
+	for _, contact := range contacts {
+		for key := range contact.CustomFields {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// rekey copies a Contact with its custom fields rekeyed through keys, keeping any keys not found.
+func rekey(contact *Contact, keys map[string]string) *Contact {
+	rekeyed := *contact
+	rekeyed.CustomFields = nil
+
+	for key, value := range contact.CustomFields {
+		if k, ok := keys[key]; ok {
+			key = k
+		}
+
+		if rekeyed.CustomFields == nil {
+			rekeyed.CustomFields = make(map[string]interface{}, len(contact.CustomFields))
+		}
+
+		rekeyed.CustomFields[key] = value
+	}
+
+	return &rekeyed
+}
+
+func (s *marketingStore) Upsert(ctx context.Context, contacts ...*Contact) error {
+	fieldIDs, _, err := s.fields(ctx, customFieldKeys(contacts...)...)
+
+	if err != nil {
+		return err
+	}
+
+	upserts := make([]*Contact, len(contacts))
+
+	for i, contact := range contacts {
+		for name := range contact.CustomFields {
+			if _, ok := fieldIDs[name]; !ok {
+				return fmt.Errorf("contacts: no field definition named %q", name)
+			}
+		}
+
+		upserts[i] = rekey(contact, fieldIDs)
+	}
+
+	return s.upsert(ctx, nil, upserts)
+}
+
+// upsert upserts Contacts and waits for the Job to finish.
+func (s *marketingStore) upsert(ctx context.Context, listIDs []string, contacts []*Contact) error {
+	if len(contacts) == 0 {
+		return nil
+	}
+
+	job, err := s.client.MarketingContacts().Upsert(ctx, listIDs, contacts...)
+
+	if err != nil {
+		return err
+	}
+
+	result, err := job.Wait(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if result.Results.ErroredCount > 0 {
+		return fmt.Errorf("contacts: %d of %d contacts were not upserted: %s", result.Results.ErroredCount, len(contacts), result.Errors)
+	}
+
+	return nil
+}
+
+// read rekeys Contacts read from the new API by field name, in case any are keyed by field definition ID.
+func (s *marketingStore) read(ctx context.Context, contacts []*Contact) error {
+	_, names, err := s.fields(ctx, customFieldKeys(contacts...)...)
+
+	if err != nil {
+		return err
+	}
+
+	for i, contact := range contacts {
+		contacts[i] = rekey(contact, names)
+	}
+
+	return nil
+}
+
+func (s *marketingStore) GetByEmail(ctx context.Context, email string) (*Contact, error) {
+	contacts, err := s.client.MarketingContacts().GetByEmails(ctx, email)
+
+	if err != nil {
+		return nil, err
+	}
+
+	contact := findContact(contacts, email)
+
+	if contact == nil {
+		return nil, &APIError{StatusCode: http.StatusNotFound}
+	}
+
+	read := []*Contact{contact}
+
+	if err := s.read(ctx, read); err != nil {
+		return nil, err
+	}
+
+	return read[0], nil
+}
+
+// contactIDs gets the IDs of the Contacts with the given email addresses, and the email addresses with none.
+func (s *marketingStore) contactIDs(ctx context.Context, emails []string) (ids, missing []string, err error) {
+	for start := 0; start < len(emails); start += maxGetByEmails {
+		batch := emails[start:min(start+maxGetByEmails, len(emails))]
+
+		contacts, err := s.client.MarketingContacts().GetByEmails(ctx, batch...)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, email := range batch {
+			if contact := findContact(contacts, email); contact != nil {
+				ids = append(ids, contact.ID)
+			} else {
+				missing = append(missing, email)
+			}
+		}
+	}
+
+	return ids, missing, nil
+}
+
+func (s *marketingStore) Delete(ctx context.Context, emails ...string) error {
+	ids, _, err := s.contactIDs(ctx, emails)
+
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	_, err = s.client.MarketingContacts().Delete(ctx, ids...)
+
+	return err
+}
+
+func (s *marketingStore) AddToList(ctx context.Context, listID string, emails ...string) error {
+	_, missing, err := s.contactIDs(ctx, emails)
+
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("contacts: no contacts with email addresses %s", strings.Join(missing, ", "))
+	}
+
+	contacts := make([]*Contact, len(emails))
+
+	for i, email := range emails {
+		contacts[i] = &Contact{Email: email}
+	}
+
+	return s.upsert(ctx, []string{listID}, contacts)
+}
+
+func (s *marketingStore) RemoveFromList(ctx context.Context, listID string, emails ...string) error {
+	ids, _, err := s.contactIDs(ctx, emails)
+
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	_, err = s.client.MarketingLists().RemoveContacts(ctx, listID, ids...)
+
+	return err
+}
+
+// All exports every Contact and streams the export.
+func (s *marketingStore) All(ctx context.Context) iter.Seq2[*Contact, error] {
+	return func(yield func(*Contact, error) bool) {
+		export, err := s.client.MarketingContacts().Export(ctx, &ContactExportOptions{Format: ExportNDJSON})
+
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		for contact, err := range export.Contacts(ctx) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			read := []*Contact{contact}
+
+			if err := s.read(ctx, read); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if !yield(read[0], nil) {
+				return
+			}
+		}
+	}
+}
+
+func (s *marketingStore) Search(ctx context.Context, query Query) ([]*Contact, error) {
+	contacts, _, err := s.client.MarketingContacts().Search(ctx, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.read(ctx, contacts); err != nil {
+		return nil, err
+	}
+
+	return contacts, nil
+}
+
+// usesField reports whether a Query refers to field.
+func usesField(q Query, field string) bool {
+	switch q := q.(type) {
+	case comparison:
+		return q.field == field
+	case in:
+		return q.field == field
+	case function:
+		return q.field == field
+	case null:
+		return q.field == field
+	case withinLast:
+		return q.field == field
+	case group:
+		for _, query := range q.queries {
+			if usesField(query, field) {
+				return true
+			}
+		}
+	case not:
+		return usesField(q.query, field)
+	}
+
+	return false
+}
+
+// contactField gets the value of a reserved or custom field of a Contact, by the name used in SGQL.
+func contactField(c *Contact, name string) interface{} {
+	switch name {
+	case "contact_id":
+		return c.ID
+	case "email":
+		return c.Email
+	case "first_name":
+		return c.FirstName
+	case "last_name":
+		return c.LastName
+	case "address_line_1":
+		return c.AddressLine1
+	case "address_line_2":
+		return c.AddressLine2
+	case "city":
+		return c.City
+	case "state_province_region":
+		return c.StateProvinceRegion
+	case "postal_code":
+		return c.PostalCode
+	case "country":
+		return c.Country
+	case "phone_number":
+		return c.PhoneNumber
+	case "alternate_emails":
+		return c.AlternateEmails
+	case "list_ids":
+		return c.ListIDs
+	case "created_at", "updated_at":
+		t := c.CreatedAt
+
+		if name == "updated_at" {
+			t = c.UpdatedAt
+		}
+
+		if t == nil {
+			return nil
+		}
+
+		return t.Unix()
+	default:
+		return c.CustomFields[name]
+	}
+}
+
+// sgqlString converts an SGQL value to the string form compared by compareValues.
+func sgqlString(value interface{}) string {
+	if t, ok := value.(time.Time); ok {
+		return strconv.FormatInt(t.Unix(), 10)
+	}
+
+	return fmt.Sprint(value)
+}
+
+// evalQuery evaluates a Query against a Contact locally, comparing values as Segment.Matches does. likes
+// are the compiled LIKE patterns of the Query, from likePatterns.
+func evalQuery(q Query, c *Contact, likes map[string]*regexp.Regexp) bool {
+	switch q := q.(type) {
+	case comparison:
+		actual := contactField(c, q.field)
+
+		switch q.operator {
+		case "LIKE", "NOT LIKE":
+			return likes[sgqlString(q.value)].MatchString(fmt.Sprint(actual)) == (q.operator == "LIKE")
+		}
+
+		cmp := compareValues(actual, sgqlString(q.value))
+
+		switch q.operator {
+		case "=":
+			return cmp == 0
+		case "!=":
+			return cmp != 0
+		case "<":
+			return cmp < 0
+		case "<=":
+			return cmp <= 0
+		case ">":
+			return cmp > 0
+		case ">=":
+			return cmp >= 0
+		}
+	case in:
+		actual := contactField(c, q.field)
+
+		for _, value := range q.values {
+			if compareValues(actual, sgqlString(value)) == 0 {
+				return !q.not
+			}
+		}
+
+		return q.not
+	case function:
+		values, _ := contactField(c, q.field).([]string)
+
+		for _, value := range values {
+			if strings.EqualFold(value, sgqlString(q.value)) {
+				return true
+			}
+		}
+	case null:
+		actual := contactField(c, q.field)
+
+		return (actual == nil || actual == "") != q.not
+	case withinLast:
+		actual, ok := numericValue(contactField(c, q.field))
+
+		return ok && actual > float64(time.Now().AddDate(0, 0, -q.days).Unix())
+	case group:
+		for _, query := range q.queries {
+			if evalQuery(query, c, likes) != (q.operator == "AND") {
+				return q.operator != "AND"
+			}
+		}
+
+		return q.operator == "AND"
+	case not:
+		return !evalQuery(q.query, c, likes)
+	}
+
+	return false
+}
+
+// likePatterns compiles the LIKE patterns of a Query into patterns, keyed by pattern, so that they are
+// compiled once per search rather than once per Contact.
+func likePatterns(q Query, patterns map[string]*regexp.Regexp) map[string]*regexp.Regexp {
+	switch q := q.(type) {
+	case comparison:
+		if q.operator == "LIKE" || q.operator == "NOT LIKE" {
+			pattern := sgqlString(q.value)
+
+			if _, ok := patterns[pattern]; !ok {
+				patterns[pattern] = likePattern(pattern)
+			}
+		}
+	case group:
+		for _, query := range q.queries {
+			likePatterns(query, patterns)
+		}
+	case not:
+		likePatterns(q.query, patterns)
+	}
+
+	return patterns
+}
+
+// likePattern converts an SGQL LIKE pattern to a case insensitive regular expression.
+func likePattern(pattern string) *regexp.Regexp {
+	var b strings.Builder
+
+	b.WriteString("(?is)^")
+
+	escaped := false
+
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			b.WriteString(".*")
+		case r == '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	b.WriteString("$")
+
+	return regexp.MustCompile(b.String())
+}
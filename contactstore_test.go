@@ -0,0 +1,316 @@
+package contacts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// storeTransport fakes the legacy and Marketing endpoints used by the ContactStores.
+type storeTransport struct {
+	// lists maps legacy List IDs to the email addresses of their members, and recipients are the email
+	// addresses of every legacy Recipient. added are the IDs of the Recipients added to a List.
+	lists      map[uint][]string
+	recipients []string
+	patched    []int
+	added      []string
+
+	// contacts maps email addresses to Contact IDs, and fields are the field definitions, by name.
+	contacts   map[string]string
+	fields     map[string]string
+	fieldLoads int
+	searched   string
+}
+
+func (x *storeTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	body := `{}`
+	status := http.StatusOK
+
+	recipientsJSON := func(emails []string) string {
+		var recipients []string
+
+		for _, email := range emails {
+			recipients = append(recipients, fmt.Sprintf(`{"id": %q, "email": %q}`, ToRecipientID(email), email))
+		}
+
+		return `{"recipients": [` + strings.Join(recipients, ",") + `]}`
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/v3/contactdb/lists":
+		var lists []string
+
+		for id := range x.lists {
+			lists = append(lists, fmt.Sprintf(`{"id": %d}`, id))
+		}
+
+		body = `{"lists": [` + strings.Join(lists, ",") + `]}`
+	case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/v3/contactdb/lists/"):
+		var ids []string
+
+		json.NewDecoder(r.Body).Decode(&ids)
+		x.added = append(x.added, ids...)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v3/contactdb/lists/"):
+		var id uint
+
+		fmt.Sscanf(r.URL.Path, "/v3/contactdb/lists/%d/recipients", &id)
+		body = recipientsJSON(x.lists[id])
+	case r.Method == http.MethodGet && r.URL.Path == "/v3/contactdb/recipients":
+		body = recipientsJSON(x.recipients)
+	case r.Method == http.MethodGet && r.URL.Path == "/v3/contactdb/recipients/search":
+		body = `{"recipients": []}`
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v3/contactdb/recipients/"):
+		status = http.StatusNotFound
+
+		for _, email := range x.recipients {
+			if r.URL.Path == "/v3/contactdb/recipients/"+ToRecipientID(email) {
+				body, status = fmt.Sprintf(`{"id": %q, "email": %q}`, ToRecipientID(email), email), http.StatusOK
+			}
+		}
+	case r.Method == http.MethodPatch && r.URL.Path == "/v3/contactdb/recipients":
+		var recipients []map[string]interface{}
+
+		json.NewDecoder(r.Body).Decode(&recipients)
+		x.patched = append(x.patched, len(recipients))
+	case r.URL.Path == "/v3/marketing/field_definitions":
+		x.fieldLoads++
+
+		var fields []string
+
+		for name, id := range x.fields {
+			fields = append(fields, fmt.Sprintf(`{"id": %q, "name": %q, "field_type": "Text"}`, id, name))
+		}
+
+		body = `{"custom_fields": [` + strings.Join(fields, ",") + `]}`
+	case r.URL.Path == "/v3/marketing/contacts/search/emails":
+		var req getByEmailsRequest
+
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var results []string
+
+		for _, email := range req.Emails {
+			if id, ok := x.contacts[email]; ok {
+				results = append(results, fmt.Sprintf(`%q: {"contact": {"id": %q, "email": %q}}`, email, id, email))
+			}
+		}
+
+		body = `{"result": {` + strings.Join(results, ",") + `}}`
+	case r.URL.Path == "/v3/marketing/contacts/search":
+		body = fmt.Sprintf(`{"result": [{"id": "1", "email": "a@example.com", "custom_fields": {%q: "dog"}}], "contact_count": 1}`, x.searched)
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+func TestEvalQuery(t *testing.T) {
+	createdAt := time.Now().AddDate(0, 0, -3)
+
+	contact := &Contact{
+		Email:        "john.doe@example.com",
+		FirstName:    "John",
+		ListIDs:      []string{"7"},
+		CreatedAt:    &createdAt,
+		CustomFields: map[string]interface{}{"age": float64(30), "pet": "dog"},
+	}
+
+	tests := []struct {
+		query Query
+		match bool
+	}{
+		{Eq("first_name", "john"), true},
+		{Like("email", "%@EXAMPLE.com"), true},
+		{Like("email", `john\_doe%`), false},
+		{Gt("age", 29), true},
+		{In("pet", "cat", "dog"), true},
+		{NotIn("pet", "cat", "dog"), false},
+		{InList("7"), true},
+		{IsNull("last_name"), true},
+		{WithinLast("created_at", 7), true},
+		{And(Gt("age", 29), Not(InList("7"))), false},
+		{Or(Lt("age", 18), Eq("pet", "dog")), true},
+	}
+
+	for _, test := range tests {
+		likes := likePatterns(test.query, make(map[string]*regexp.Regexp))
+
+		if evalQuery(test.query, contact, likes) != test.match {
+			sgql, _ := BuildSGQL(test.query)
+			t.Errorf("%s: expected %v", sgql, test.match)
+		}
+	}
+
+	likes := likePatterns(And(Like("email", "a%"), Not(Or(Like("email", "b%"), Like("first_name", "a%")))), make(map[string]*regexp.Regexp))
+
+	if len(likes) != 2 || likes["a%"] == nil || likes["b%"] == nil {
+		t.Error(likes)
+	}
+}
+
+func TestClient_ContactStore(t *testing.T) {
+	if _, err := client.ContactStore("nosql"); err == nil {
+		t.Fail()
+	}
+
+	ctx := context.Background()
+
+	store, err := client.ContactStore(StoreLegacy)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	err = store.Upsert(ctx, &Contact{Email: "store@example.com", FirstName: "Store"})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	contact, err := store.GetByEmail(ctx, "store@example.com")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if contact.FirstName != "Store" {
+		t.Fail()
+	}
+}
+
+func TestLegacyStore_Search_inList(t *testing.T) {
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: &storeTransport{
+		lists:      map[uint][]string{7: {"a@example.com"}, 8: {"a@example.com", "b@example.com"}},
+		recipients: []string{"a@example.com", "b@example.com", "c@example.com"},
+	}}
+
+	store, _ := c.ContactStore(StoreLegacy)
+
+	matches, err := store.Search(context.Background(), Or(InList("7"), Not(InList("8"))))
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	var emails []string
+
+	for _, contact := range matches {
+		emails = append(emails, contact.Email)
+	}
+
+	if !slices.Equal(emails, []string{"a@example.com", "c@example.com"}) {
+		t.Error(emails)
+	}
+}
+
+func TestLegacyStore_Upsert_batches(t *testing.T) {
+	transport := &storeTransport{}
+
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: transport}
+
+	contacts := make([]*Contact, 1500)
+
+	for i := range contacts {
+		contacts[i] = &Contact{Email: fmt.Sprintf("%d@example.com", i)}
+	}
+
+	store, _ := c.ContactStore(StoreLegacy)
+
+	if err := store.Upsert(context.Background(), contacts...); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if !slices.Equal(transport.patched, []int{1000, 500}) {
+		t.Error(transport.patched)
+	}
+}
+
+func TestMarketingStore_AddToList_unknownContact(t *testing.T) {
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: &storeTransport{contacts: map[string]string{"a@example.com": "1"}}}
+
+	store, _ := c.ContactStore(StoreMarketing)
+
+	err := store.AddToList(context.Background(), "list", "a@example.com", "nobody@example.com")
+
+	if err == nil || !strings.Contains(err.Error(), "nobody@example.com") {
+		t.Error(err)
+	}
+}
+
+func TestLegacyStore_AddToList_unknownContact(t *testing.T) {
+	transport := &storeTransport{recipients: []string{"a@example.com"}}
+
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: transport}
+
+	store, _ := c.ContactStore(StoreLegacy)
+
+	err := store.AddToList(context.Background(), "7", "a@example.com", "nobody@example.com")
+
+	if err == nil || !strings.Contains(err.Error(), "nobody@example.com") || len(transport.added) != 0 {
+		t.Error(err, transport.added)
+	}
+
+	if err := store.AddToList(context.Background(), "7", "A@example.com"); err != nil {
+		t.Error(err)
+	}
+
+	if !slices.Equal(transport.added, []string{ToRecipientID("a@example.com")}) {
+		t.Error(transport.added)
+	}
+}
+
+func TestMarketingStore_fields(t *testing.T) {
+	transport := &storeTransport{fields: map[string]string{"pet": "e1_T"}, searched: "e1_T"}
+
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: transport}
+
+	store, _ := c.ContactStore(StoreMarketing)
+	ctx := context.Background()
+
+	// contacts keyed by ID or by a known name don't reload the fields
+	for _, key := range []string{"e1_T", "pet"} {
+		transport.searched = key
+
+		matches, err := store.Search(ctx, Eq("pet", "dog"))
+
+		if err != nil || matches[0].CustomFields["pet"] != "dog" || transport.fieldLoads != 1 {
+			t.Error(matches, err, transport.fieldLoads)
+		}
+	}
+
+	// a field created since they were loaded reloads them
+	transport.fields["shoe_size"], transport.searched = "e2_T", "e2_T"
+
+	matches, err := store.Search(ctx, Eq("pet", "dog"))
+
+	if err != nil || matches[0].CustomFields["shoe_size"] != "dog" || transport.fieldLoads != 2 {
+		t.Error(matches, err, transport.fieldLoads)
+	}
+
+	// as does upserting an unknown field, which is an error if it still doesn't exist
+	err = store.Upsert(ctx, &Contact{Email: "a@example.com", CustomFields: map[string]interface{}{"hat_size": 7}})
+
+	if err == nil || transport.fieldLoads != 3 {
+		t.Error(err, transport.fieldLoads)
+	}
+}
@@ -0,0 +1,307 @@
+package contacts
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// ConflictPolicy decides what CopyList does when a Recipient already exists on the destination with
+// different field values.
+type ConflictPolicy int
+
+const (
+	// ConflictOverwrite overwrites the destination's values with the source's field by field. Fields which
+	// the source doesn't set keep their values on the destination.
+	ConflictOverwrite ConflictPolicy = iota
+	// ConflictKeepDestination keeps the destination's values, only filling in fields which are empty there.
+	ConflictKeepDestination
+	// ConflictFail stops the copy with a *FieldConflictError.
+	ConflictFail
+)
+
+// FieldConflictError is returned by CopyList with ConflictFail when a field differs between the source
+// and destination Recipient.
+type FieldConflictError struct {
+	RecipientID string
+	Field       string
+	Source      interface{}
+	Destination interface{}
+}
+
+func (e *FieldConflictError) Error() string {
+	return fmt.Sprintf("contacts: recipient %s has conflicting values for %s: source %v, destination %v", e.RecipientID, e.Field, e.Source, e.Destination)
+}
+
+// CopyListOptions configure CopyList.
+type CopyListOptions struct {
+	// Into is the ID of an existing destination List to merge into. If it is zero, a new List is created.
+	Into uint
+
+	// Name is the name of the new List. It defaults to the name of the source List.
+	Name string
+
+	// Conflicts decides how differing field values are handled for Recipients which already exist on
+	// the destination.
+	Conflicts ConflictPolicy
+}
+
+// CopyListResult summarises a CopyList.
+type CopyListResult struct {
+	List      *List
+	Copied    int
+	Conflicts int
+	Rejected  int
+}
+
+// CopyList copies a List and its Recipients, with their custom field values, from one Client to another,
+// e.g. between accounts or into a subuser with OnBehalfOf. Custom fields used by the source are created on
+// the destination if they don't exist. Recipients are updated rather than replaced, so that fields the source
+// doesn't set are never cleared on the destination. Unless opts.Conflicts is ConflictOverwrite, each
+// Recipient is first looked up on the destination so that differing values can be resolved.
+func CopyList(ctx context.Context, src *Client, srcListID uint, dst *Client, opts *CopyListOptions) (*CopyListResult, error) {
+	if opts == nil {
+		opts = &CopyListOptions{}
+	}
+
+	src, dst = src.WithContext(ctx), dst.WithContext(ctx)
+
+	srcList, err := src.Lists().Get(srcListID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := copyCustomFields(src, dst); err != nil {
+		return nil, err
+	}
+
+	result := &CopyListResult{}
+
+	if opts.Into != 0 {
+		result.List, err = dst.Lists().Get(opts.Into)
+	} else {
+		name := opts.Name
+
+		if name == "" {
+			name = srcList.Name
+		}
+
+		result.List, err = dst.Lists().Create(name)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	batch := make([]*Recipient, 0, maxBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		fields := make([]map[string]interface{}, len(batch))
+
+		for i, recipient := range batch {
+			fields[i] = recipientFields(recipient)
+		}
+
+		resp, err := dst.Recipients().updateFields(fields)
+
+		if err != nil {
+			return err
+		}
+
+		var ids []string
+
+		for i, recipient := range batch {
+			if contains(resp.ErrorIndices, i) {
+				result.Rejected++
+				continue
+			}
+
+			ids = append(ids, recipientID(recipient))
+		}
+
+		if len(ids) > 0 {
+			if err := dst.Lists().AddRecipientsByIDs(result.List.ID, ids...); err != nil {
+				return err
+			}
+		}
+
+		result.Copied += len(ids)
+		batch = batch[:0]
+
+		return nil
+	}
+
+	for recipient, err := range src.Lists().AllRecipients(srcListID) {
+		if err != nil {
+			return result, err
+		}
+
+		copied := &Recipient{
+			Email:        recipient.Email,
+			FirstName:    recipient.FirstName,
+			LastName:     recipient.LastName,
+			CustomFields: recipient.CustomFields,
+		}
+
+		if opts.Conflicts != ConflictOverwrite {
+			existing, err := dst.Recipients().Get(recipientID(recipient))
+
+			if err != nil && !IsNotFound(err) {
+				return result, err
+			}
+
+			if existing != nil {
+				merged, conflicts, err := mergeRecipient(copied, existing, opts.Conflicts)
+
+				if err != nil {
+					return result, err
+				}
+
+				copied = merged
+				result.Conflicts += conflicts
+			}
+		}
+
+		batch = append(batch, copied)
+
+		if len(batch) == maxBatchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	return result, flush()
+}
+
+// recipientFields gets a Recipient's fields by name for RecipientClient.updateFields, leaving out those which
+// aren't set.
+func recipientFields(r *Recipient) map[string]interface{} {
+	fields := map[string]interface{}{"email": r.Email}
+
+	if r.FirstName != "" {
+		fields["first_name"] = r.FirstName
+	}
+
+	if r.LastName != "" {
+		fields["last_name"] = r.LastName
+	}
+
+	for _, field := range r.CustomFields {
+		if field.Value != nil {
+			fields[field.Name] = field.Value
+		}
+	}
+
+	return fields
+}
+
+// copyCustomFields creates the source's custom fields on the destination if they don't already exist.
+func copyCustomFields(src, dst *Client) error {
+	srcFields, err := src.CustomFields().List()
+
+	if err != nil {
+		return err
+	}
+
+	dstFields, err := dst.CustomFields().List()
+
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]*CustomField, len(dstFields))
+
+	for _, field := range dstFields {
+		existing[field.Name] = field
+	}
+
+	for _, field := range srcFields {
+		if dstField, ok := existing[field.Name]; ok {
+			if dstField.Type != field.Type {
+				return fmt.Errorf("contacts: custom field %q is %s on the source but %s on the destination", field.Name, field.Type, dstField.Type)
+			}
+
+			continue
+		}
+
+		if err := dst.CustomFields().Create(&CustomField{Name: field.Name, Type: field.Type}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mergeRecipient merges a source Recipient with the existing destination Recipient according to policy,
+// returning the merged Recipient and the number of fields which conflicted.
+func mergeRecipient(src, dst *Recipient, policy ConflictPolicy) (*Recipient, int, error) {
+	merged := &Recipient{Email: src.Email}
+	conflicts := 0
+
+	resolve := func(field string, srcValue, dstValue interface{}) (interface{}, error) {
+		if isEmptyValue(srcValue) {
+			return dstValue, nil
+		}
+
+		if isEmptyValue(dstValue) || reflect.DeepEqual(srcValue, dstValue) {
+			return srcValue, nil
+		}
+
+		conflicts++
+
+		switch policy {
+		case ConflictKeepDestination:
+			return dstValue, nil
+		case ConflictFail:
+			return nil, &FieldConflictError{RecipientID: recipientID(src), Field: field, Source: srcValue, Destination: dstValue}
+		default:
+			return srcValue, nil
+		}
+	}
+
+	firstName, err := resolve("first_name", src.FirstName, dst.FirstName)
+
+	if err != nil {
+		return nil, conflicts, err
+	}
+
+	lastName, err := resolve("last_name", src.LastName, dst.LastName)
+
+	if err != nil {
+		return nil, conflicts, err
+	}
+
+	merged.FirstName, merged.LastName = firstName.(string), lastName.(string)
+
+	names := make(map[string]bool)
+
+	for _, f := range append(append([]CustomField{}, src.CustomFields...), dst.CustomFields...) {
+		if names[f.Name] {
+			continue
+		}
+
+		names[f.Name] = true
+
+		value, err := resolve(f.Name, src.CustomField(f.Name), dst.CustomField(f.Name))
+
+		if err != nil {
+			return nil, conflicts, err
+		}
+
+		if value != nil {
+			merged.CustomFields = append(merged.CustomFields, CustomField{Name: f.Name, Value: value})
+		}
+	}
+
+	return merged, conflicts, nil
+}
+
+func isEmptyValue(v interface{}) bool {
+	return v == nil || v == ""
+}
@@ -0,0 +1,139 @@
+package contacts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// copyTransport fakes a source List with one Recipient, and records the Recipients sent to the destination.
+type copyTransport struct {
+	methods []string
+	sent    []map[string]interface{}
+}
+
+func (x *copyTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	body := `{}`
+
+	switch {
+	case r.URL.Path == "/v3/contactdb/custom_fields":
+		body = `{"custom_fields": [{"id": 1, "name": "pet", "type": "text"}]}`
+	case r.URL.Path == "/v3/contactdb/lists" || r.URL.Path == "/v3/contactdb/lists/1":
+		body = `{"id": 1, "name": "source"}`
+	case r.URL.Path == "/v3/contactdb/lists/1/recipients" && r.Method == http.MethodGet:
+		body = `{"recipients": [{"id": "abc", "email": "copy@example.com", "first_name": "Copy", "custom_fields": [{"name": "pet", "value": "dog"}, {"name": "beer", "value": null}]}]}`
+	case r.URL.Path == "/v3/contactdb/recipients":
+		x.methods = append(x.methods, r.Method)
+		json.NewDecoder(r.Body).Decode(&x.sent)
+		body = `{"persisted_recipients": ["abc"]}`
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+func TestMergeRecipient(t *testing.T) {
+	src := &Recipient{Email: "merge@example.com", FirstName: "Source", CustomFields: []CustomField{
+		{Name: "pet", Value: "dog"},
+		{Name: "beer", Value: "Budweiser"},
+	}}
+
+	dst := &Recipient{Email: "merge@example.com", FirstName: "Destination", LastName: "Kept", CustomFields: []CustomField{
+		{Name: "pet", Value: "cat"},
+	}}
+
+	merged, conflicts, err := mergeRecipient(src, dst, ConflictKeepDestination)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if conflicts != 2 || merged.FirstName != "Destination" || merged.LastName != "Kept" || merged.CustomField("pet") != "cat" || merged.CustomField("beer") != "Budweiser" {
+		t.Fail()
+	}
+
+	merged, _, err = mergeRecipient(src, dst, ConflictOverwrite)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if merged.FirstName != "Source" || merged.LastName != "Kept" || merged.CustomField("pet") != "dog" {
+		t.Fail()
+	}
+
+	var conflictErr *FieldConflictError
+
+	_, _, err = mergeRecipient(src, dst, ConflictFail)
+
+	if !errors.As(err, &conflictErr) || conflictErr.Field != "first_name" {
+		t.Error(err)
+	}
+}
+
+func TestCopyList_overwrite(t *testing.T) {
+	transport := &copyTransport{}
+
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: transport}
+
+	result, err := CopyList(context.Background(), c, 1, c, &CopyListOptions{Into: 1})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	// the Recipient is updated, not replaced, so fields which the source doesn't set are left out
+	if result.Copied != 1 || len(transport.methods) != 1 || transport.methods[0] != http.MethodPatch || len(transport.sent) != 1 {
+		t.Error(result, transport.methods)
+		t.FailNow()
+	}
+
+	sent := transport.sent[0]
+
+	if len(sent) != 3 || sent["email"] != "copy@example.com" || sent["first_name"] != "Copy" || sent["pet"] != "dog" {
+		t.Error(sent)
+	}
+}
+
+func TestCopyList(t *testing.T) {
+	r := &Recipient{Email: "copy.list@example.com", FirstName: "Copy"}
+
+	_, err := client.Recipients().Add(r)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	list, err := client.Lists().CreateWithRecipients("copy_list_source", []string{r.ID})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	result, err := CopyList(context.Background(), client, list.ID, client, &CopyListOptions{
+		Name:      "copy_list_destination",
+		Conflicts: ConflictKeepDestination,
+	})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if result.List == nil || result.List.Name != "copy_list_destination" {
+		t.Fail()
+	}
+}
@@ -0,0 +1,135 @@
+package contacts
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CredentialProvider supplies the API key used to authenticate a request. It is consulted before every
+// request, so implementations may return a different key over time and must be safe for concurrent use.
+type CredentialProvider interface {
+	APIKey(ctx context.Context) (string, error)
+}
+
+// RefreshingCredentialProvider is a CredentialProvider which caches its API key. Refresh is called when
+// SendGrid rejects a key with 401 Unauthorized, after which the request is retried once with the new key.
+type RefreshingCredentialProvider interface {
+	CredentialProvider
+	Refresh(ctx context.Context) error
+}
+
+func (c *Client) credentials() CredentialProvider {
+	if c.Credentials != nil {
+		return c.Credentials
+	}
+
+	return StaticCredentials(c.APIKey)
+}
+
+// StaticCredentials always provides the same API key.
+type StaticCredentials string
+
+// APIKey returns the API key.
+func (s StaticCredentials) APIKey(ctx context.Context) (string, error) {
+	if s == "" {
+		return "", ErrMissingAPIKey
+	}
+
+	return string(s), nil
+}
+
+// EnvCredentials provides the API key held in the named environment variable, read on every request.
+type EnvCredentials string
+
+// APIKey returns the value of the environment variable.
+func (e EnvCredentials) APIKey(ctx context.Context) (string, error) {
+	apiKey := os.Getenv(string(e))
+
+	if apiKey == "" {
+		return "", fmt.Errorf("contacts: environment variable %s is empty: %w", string(e), ErrMissingAPIKey)
+	}
+
+	return apiKey, nil
+}
+
+// FileCredentials provides an API key read from a file, such as a mounted secret. The file is re-read
+// whenever its modification time or size changes, so the key can be rotated by rewriting the file.
+type FileCredentials struct {
+	Path string
+
+	mu      sync.Mutex
+	apiKey  string
+	modTime time.Time
+	size    int64
+}
+
+// NewFileCredentials creates FileCredentials for the file at path, reading it immediately so that a
+// missing or empty file is reported up front.
+func NewFileCredentials(path string) (*FileCredentials, error) {
+	f := &FileCredentials{Path: path}
+
+	if err := f.Refresh(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// APIKey returns the API key in the file, re-reading the file if it has changed.
+func (f *FileCredentials) APIKey(ctx context.Context) (string, error) {
+	info, err := os.Stat(f.Path)
+
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.apiKey == "" || !info.ModTime().Equal(f.modTime) || info.Size() != f.size {
+		if err := f.load(); err != nil {
+			return "", err
+		}
+	}
+
+	return f.apiKey, nil
+}
+
+// Refresh re-reads the file.
+func (f *FileCredentials) Refresh(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.load()
+}
+
+func (f *FileCredentials) load() error {
+	info, err := os.Stat(f.Path)
+
+	if err != nil {
+		return err
+	}
+
+	b, err := ioutil.ReadFile(f.Path)
+
+	if err != nil {
+		return err
+	}
+
+	apiKey := strings.TrimSpace(string(b))
+
+	if apiKey == "" {
+		return fmt.Errorf("contacts: credentials file %s is empty: %w", f.Path, ErrMissingAPIKey)
+	}
+
+	f.apiKey = apiKey
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package contacts
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "apikey")
+
+	if err := ioutil.WriteFile(path, []byte("first\n"), 0600); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	credentials, err := NewFileCredentials(path)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if key, err := credentials.APIKey(context.Background()); err != nil || key != "first" {
+		t.Fail()
+	}
+
+	if err := ioutil.WriteFile(path, []byte("second-key\n"), 0600); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if key, err := credentials.APIKey(context.Background()); err != nil || key != "second-key" {
+		t.Fail()
+	}
+}
+
+func TestEnvCredentials(t *testing.T) {
+	os.Setenv("CONTACTS_TEST_APIKEY", "")
+
+	if _, err := EnvCredentials("CONTACTS_TEST_APIKEY").APIKey(context.Background()); err == nil {
+		t.Fail()
+	}
+}
+
+func TestNewClient_MissingAPIKey(t *testing.T) {
+	if _, err := NewClient(""); !errors.Is(err, ErrMissingAPIKey) {
+		t.Error(err)
+	}
+
+	if _, err := NewWithCredentials(StaticCredentials("")); !errors.Is(err, ErrMissingAPIKey) {
+		t.Error(err)
+	}
+
+	if _, err := NewClient("apikey"); err != nil {
+		t.Error(err)
+	}
+}
+
+type rotatingCredentials struct {
+	keys []string
+}
+
+func (r *rotatingCredentials) APIKey(ctx context.Context) (string, error) {
+	return r.keys[0], nil
+}
+
+func (r *rotatingCredentials) Refresh(ctx context.Context) error {
+	r.keys = r.keys[1:]
+
+	return nil
+}
+
+type unauthorizedTransport struct {
+	validKey string
+	attempts int
+}
+
+func (x *unauthorizedTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	x.attempts++
+
+	status := http.StatusOK
+
+	if r.Header.Get("Authorization") != "Bearer "+x.validKey {
+		status = http.StatusUnauthorized
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Body:       ioutil.NopCloser(strings.NewReader(`{"lists": []}`)),
+		Request:    r,
+	}, nil
+}
+
+func TestClient_RetryWithRefreshedCredentials(t *testing.T) {
+	transport := &unauthorizedTransport{validKey: "new"}
+
+	c, err := NewWithCredentials(&rotatingCredentials{keys: []string{"old", "new"}})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	c.HTTPClient = &http.Client{Transport: transport}
+
+	_, err = c.Lists().List()
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if transport.attempts != 2 {
+		t.Fail()
+	}
+}
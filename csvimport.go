@@ -0,0 +1,317 @@
+package contacts
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxBatchSize is the largest number of recipients SendGrid accepts in a single request.
+const maxBatchSize = 1000
+
+// reservedRecipientFields are the reserved fields which can be set on a Recipient.
+var reservedRecipientFields = map[string]func(r *Recipient, value string){
+	"email":      func(r *Recipient, value string) { r.Email = value },
+	"first_name": func(r *Recipient, value string) { r.FirstName = value },
+	"last_name":  func(r *Recipient, value string) { r.LastName = value },
+}
+
+// dateLayouts are the formats accepted for date custom fields, in addition to unix timestamps.
+var dateLayouts = []string{
+	"01/02/2006",
+	"2006-01-02",
+	time.RFC3339,
+}
+
+// RowError is an error in a single row of a CSV file.
+type RowError struct {
+	Line   int
+	Column string
+	Err    error
+}
+
+func (e *RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("contacts: line %d: %v", e.Line, e.Err)
+	}
+
+	return fmt.Sprintf("contacts: line %d, column %q: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+type csvColumn struct {
+	header   string
+	reserved func(r *Recipient, value string)
+	custom   *CustomField
+}
+
+// CSVReader reads Recipients from a CSV file with a header row.
+type CSVReader struct {
+	r       *csv.Reader
+	columns []*csvColumn
+}
+
+// NewCSVReader creates a CSVReader which maps the CSV columns named in columns to reserved fields
+// ("email", "first_name", "last_name") or to the custom fields in fields. If columns is nil, each column is
+// mapped to the field with the same name as its header. Columns which aren't mapped are ignored.
+func NewCSVReader(r io.Reader, columns map[string]string, fields []*CustomField) (*CSVReader, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+
+	if err != nil {
+		return nil, err
+	}
+
+	customFields := make(map[string]*CustomField, len(fields))
+
+	for _, field := range fields {
+		customFields[field.Name] = field
+	}
+
+	reader := &CSVReader{r: cr, columns: make([]*csvColumn, len(header))}
+	hasEmail := false
+
+	for i, h := range header {
+		name := h
+
+		if columns != nil {
+			var ok bool
+
+			if name, ok = columns[h]; !ok {
+				continue
+			}
+		}
+
+		if setter, ok := reservedRecipientFields[name]; ok {
+			reader.columns[i] = &csvColumn{header: h, reserved: setter}
+			hasEmail = hasEmail || name == "email"
+		} else if field, ok := customFields[name]; ok {
+			reader.columns[i] = &csvColumn{header: h, custom: field}
+		} else if columns != nil {
+			return nil, fmt.Errorf("contacts: column %q is mapped to unknown field %q", h, name)
+		}
+	}
+
+	if !hasEmail {
+		return nil, errors.New("contacts: no column is mapped to email")
+	}
+
+	return reader, nil
+}
+
+// Read reads the next Recipient from the CSV file, returning the line on which its row starts. At the end of
+// the file, Read returns io.EOF. If the row is invalid, Read returns a *RowError and reading may continue.
+func (r *CSVReader) Read() (*Recipient, int, error) {
+	record, err := r.r.Read()
+
+	if err != nil {
+		var parseErr *csv.ParseError
+
+		if errors.As(err, &parseErr) {
+			return nil, parseErr.StartLine, &RowError{Line: parseErr.StartLine, Err: parseErr.Err}
+		}
+
+		return nil, 0, err
+	}
+
+	line, _ := r.r.FieldPos(0)
+	recipient := &Recipient{}
+
+	for i, value := range record {
+		if i >= len(r.columns) || r.columns[i] == nil {
+			continue
+		}
+
+		column := r.columns[i]
+		value = strings.TrimSpace(value)
+
+		if value == "" {
+			continue
+		}
+
+		if column.reserved != nil {
+			column.reserved(recipient, value)
+			continue
+		}
+
+		converted, err := convertFieldValue(column.custom.Type, value)
+
+		if err != nil {
+			return nil, line, &RowError{Line: line, Column: column.header, Err: err}
+		}
+
+		recipient.CustomFields = append(recipient.CustomFields, CustomField{
+			ID:    column.custom.ID,
+			Name:  column.custom.Name,
+			Type:  column.custom.Type,
+			Value: converted,
+		})
+	}
+
+	if recipient.Email == "" {
+		return nil, line, &RowError{Line: line, Err: errors.New("email is required")}
+	}
+
+	return recipient, line, nil
+}
+
+func convertFieldValue(fieldType FieldType, value string) (interface{}, error) {
+	switch fieldType {
+	case FieldTypeNumber:
+		return strconv.ParseFloat(value, 64)
+	case FieldTypeDate:
+		if ts, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return ts, nil
+		}
+
+		for _, layout := range dateLayouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				return t.Unix(), nil
+			}
+		}
+
+		return nil, fmt.Errorf("invalid date %q", value)
+	default:
+		return value, nil
+	}
+}
+
+// CSVImportOptions configure RecipientClient.ImportCSV.
+type CSVImportOptions struct {
+	// Columns maps CSV column headers to field names, as in NewCSVReader.
+	Columns map[string]string
+
+	// BatchSize is the number of Recipients added per request. It defaults to, and may not exceed, 1000.
+	BatchSize int
+
+	// ListID, if set, is the List to which every imported Recipient is added.
+	ListID uint
+}
+
+// CSVImportResult summarises a CSV import.
+type CSVImportResult struct {
+	Rows         int
+	NewCount     int
+	UpdatedCount int
+	RowErrors    []*RowError
+}
+
+// ImportCSV reads Recipients from a CSV file and adds them in batches, optionally adding them to a List.
+// Custom field columns are validated and converted against the account's custom fields. Invalid rows,
+// including those rejected by SendGrid, are reported in RowErrors and do not stop the import.
+func (c *RecipientClient) ImportCSV(r io.Reader, opts *CSVImportOptions) (*CSVImportResult, error) {
+	if opts == nil {
+		opts = &CSVImportOptions{}
+	}
+
+	batchSize := opts.BatchSize
+
+	if batchSize <= 0 || batchSize > maxBatchSize {
+		batchSize = maxBatchSize
+	}
+
+	fields, err := c.client.CustomFields().List()
+
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := NewCSVReader(r, opts.Columns, fields)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CSVImportResult{}
+
+	var batch []*Recipient
+	var lines []int
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		resp, err := c.Add(batch...)
+
+		if err != nil {
+			return err
+		}
+
+		result.NewCount += resp.NewCount
+		result.UpdatedCount += resp.UpdatedCount
+
+		for _, e := range resp.Errors {
+			for _, index := range e.ErrorIndices {
+				result.RowErrors = append(result.RowErrors, &RowError{Line: lines[index], Err: errors.New(e.Message)})
+			}
+		}
+
+		if opts.ListID != 0 {
+			var ids []string
+
+			for index, recipient := range batch {
+				if !contains(resp.ErrorIndices, index) {
+					ids = append(ids, recipientID(recipient))
+				}
+			}
+
+			if len(ids) > 0 {
+				if err := c.client.Lists().AddRecipientsByIDs(opts.ListID, ids...); err != nil {
+					return err
+				}
+			}
+		}
+
+		batch, lines = batch[:0], lines[:0]
+
+		return nil
+	}
+
+	for {
+		recipient, line, err := reader.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		var rowErr *RowError
+
+		if errors.As(err, &rowErr) {
+			result.RowErrors = append(result.RowErrors, rowErr)
+			continue
+		} else if err != nil {
+			return result, err
+		}
+
+		result.Rows++
+		batch = append(batch, recipient)
+		lines = append(lines, line)
+
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	return result, flush()
+}
+
+// recipientID returns the ID of a Recipient, deriving it from the email address if SendGrid did not return one.
+func recipientID(r *Recipient) string {
+	if r.ID != "" {
+		return r.ID
+	}
+
+	return ToRecipientID(strings.ToLower(strings.TrimSpace(r.Email)))
+}
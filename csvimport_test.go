@@ -0,0 +1,96 @@
+package contacts
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCSVReader_Read(t *testing.T) {
+	input := "Email Address,Given Name,Pet,Age,Ignored\n" +
+		"john.doe@example.com,John,dog,42,x\n" +
+		"jane.doe@example.com,Jane,cat,not a number,y\n" +
+		",Nobody,,,\n"
+
+	fields := []*CustomField{
+		{ID: 1, Name: "pet", Type: "text"},
+		{ID: 2, Name: "age", Type: "number"},
+	}
+
+	reader, err := NewCSVReader(strings.NewReader(input), map[string]string{
+		"Email Address": "email",
+		"Given Name":    "first_name",
+		"Pet":           "pet",
+		"Age":           "age",
+	}, fields)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	r, line, err := reader.Read()
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if line != 2 || r.Email != "john.doe@example.com" || r.FirstName != "John" || len(r.CustomFields) != 2 {
+		t.Fail()
+	}
+
+	if r.CustomFields[1].Value != float64(42) {
+		t.Fail()
+	}
+
+	var rowErr *RowError
+
+	if _, _, err = reader.Read(); !errors.As(err, &rowErr) || rowErr.Line != 3 || rowErr.Column != "Age" {
+		t.Error(err)
+	}
+
+	if _, _, err = reader.Read(); !errors.As(err, &rowErr) || rowErr.Line != 4 {
+		t.Error(err)
+	}
+
+	if _, _, err = reader.Read(); err != io.EOF {
+		t.Error(err)
+	}
+}
+
+func TestNewCSVReader_UnknownField(t *testing.T) {
+	_, err := NewCSVReader(strings.NewReader("email,beer\n"), map[string]string{
+		"email": "email",
+		"beer":  "favourite_beer",
+	}, nil)
+
+	if err == nil {
+		t.Fail()
+	}
+}
+
+func TestRecipientClient_ImportCSV(t *testing.T) {
+	list, err := client.Lists().Create("csv_import")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	input := "email,first_name,last_name\n" +
+		"csv.one@example.com,CSV,One\n" +
+		"csv.two@example.com,CSV,Two\n"
+
+	result, err := client.Recipients().ImportCSV(strings.NewReader(input), &CSVImportOptions{ListID: list.ID})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if result.Rows != 2 || len(result.RowErrors) != 0 {
+		t.Fail()
+	}
+}
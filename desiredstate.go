@@ -0,0 +1,409 @@
+package contacts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is the desired state of an account's custom fields, lists and segments. Objects are identified by name.
+type Spec struct {
+	CustomFields []SpecCustomField `json:"custom_fields" yaml:"custom_fields"`
+	Lists        []SpecList        `json:"lists" yaml:"lists"`
+	Segments     []SpecSegment     `json:"segments" yaml:"segments"`
+}
+
+// SpecCustomField is a desired custom field.
+type SpecCustomField struct {
+	Name string    `json:"name" yaml:"name"`
+	Type FieldType `json:"type" yaml:"type"`
+}
+
+// SpecList is a desired List.
+type SpecList struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+// SpecSegment is a desired Segment. List is the name of the List the Segment is scoped to, if any.
+type SpecSegment struct {
+	Name       string      `json:"name" yaml:"name"`
+	List       string      `json:"list,omitempty" yaml:"list,omitempty"`
+	Conditions []Condition `json:"conditions" yaml:"conditions"`
+}
+
+// ErrEmptySpec is returned for a Spec with nothing in it, which is almost always a mistake such as an empty
+// file, and would delete every object when pruned.
+var ErrEmptySpec = errors.New("contacts: spec is empty")
+
+// ParseSpec reads a Spec from YAML or JSON. A document with none of the Spec's keys, e.g. an empty or
+// all-comment file, returns ErrEmptySpec.
+func ParseSpec(r io.Reader) (*Spec, error) {
+	b, err := ioutil.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var keys map[string]interface{}
+
+	if err := yaml.Unmarshal(b, &keys); err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, ErrEmptySpec
+	}
+
+	var spec Spec
+
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+
+	if err := dec.Decode(&spec); err != nil {
+		return nil, err
+	}
+
+	return &spec, spec.validate()
+}
+
+// empty reports whether the Spec has no custom fields, lists or segments.
+func (s *Spec) empty() bool {
+	return len(s.CustomFields) == 0 && len(s.Lists) == 0 && len(s.Segments) == 0
+}
+
+func (s *Spec) validate() error {
+	seen := make(map[string]bool)
+
+	check := func(kind, name string) error {
+		if name == "" {
+			return fmt.Errorf("contacts: %s with no name in spec", kind)
+		}
+
+		if seen[kind+"/"+name] {
+			return fmt.Errorf("contacts: %s %q is in spec more than once", kind, name)
+		}
+
+		seen[kind+"/"+name] = true
+
+		return nil
+	}
+
+	for _, field := range s.CustomFields {
+		if err := check("custom field", field.Name); err != nil {
+			return err
+		}
+	}
+
+	for _, list := range s.Lists {
+		if err := check("list", list.Name); err != nil {
+			return err
+		}
+	}
+
+	for _, segment := range s.Segments {
+		if err := check("segment", segment.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ChangeAction is the action a Change takes.
+type ChangeAction string
+
+const (
+	ChangeCreate  ChangeAction = "create"
+	ChangeUpdate  ChangeAction = "update"
+	ChangeReplace ChangeAction = "replace"
+	ChangeDelete  ChangeAction = "delete"
+)
+
+var changeSymbols = map[ChangeAction]string{
+	ChangeCreate:  "+",
+	ChangeUpdate:  "~",
+	ChangeReplace: "-/+",
+	ChangeDelete:  "-",
+}
+
+// Change is a single step of a Plan.
+type Change struct {
+	Action ChangeAction
+	Kind   string
+	Name   string
+	Detail string
+
+	apply func(p *Plan) error
+}
+
+func (c *Change) String() string {
+	s := fmt.Sprintf("%s %s %s %q", changeSymbols[c.Action], c.Action, c.Kind, c.Name)
+
+	if c.Detail != "" {
+		s += " (" + c.Detail + ")"
+	}
+
+	return s
+}
+
+// Plan is the set of Changes needed to bring an account to the state of a Spec.
+type Plan struct {
+	Changes []*Change
+
+	client *Client
+	lists  map[string]uint
+}
+
+// Empty reports whether the account already matches the Spec.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String describes the Plan, one Change per line.
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "No changes. The account matches the spec.\n"
+	}
+
+	var b strings.Builder
+
+	counts := make(map[ChangeAction]int)
+
+	for _, change := range p.Changes {
+		b.WriteString(change.String())
+		b.WriteByte('\n')
+		counts[change.Action]++
+	}
+
+	fmt.Fprintf(&b, "\nPlan: %d to create, %d to update, %d to replace, %d to delete.\n",
+		counts[ChangeCreate], counts[ChangeUpdate], counts[ChangeReplace], counts[ChangeDelete])
+
+	return b.String()
+}
+
+// PlanSpec compares the Spec with the account's custom fields, lists and segments, and returns the Changes
+// needed to make them match. If prune is set, objects which are not in the Spec are deleted; to guard against
+// deleting everything by mistake, pruning with an empty Spec returns ErrEmptySpec.
+func (c *Client) PlanSpec(ctx context.Context, spec *Spec, prune bool) (*Plan, error) {
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+
+	if prune && spec.empty() {
+		return nil, ErrEmptySpec
+	}
+
+	c = c.WithContext(ctx)
+
+	fields, err := c.CustomFields().List()
+
+	if err != nil {
+		return nil, err
+	}
+
+	lists, err := c.Lists().List()
+
+	if err != nil {
+		return nil, err
+	}
+
+	segments, err := c.Segments().List()
+
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{client: c, lists: make(map[string]uint)}
+
+	var deletes []*Change
+
+	liveFields := make(map[string]*CustomField)
+
+	for _, field := range fields {
+		liveFields[field.Name] = field
+	}
+
+	wantFields := make(map[string]bool)
+
+	for _, want := range spec.CustomFields {
+		wantFields[want.Name] = true
+
+		live, ok := liveFields[want.Name]
+
+		if !ok {
+			plan.add(&Change{Action: ChangeCreate, Kind: "custom field", Name: want.Name, Detail: "type " + string(want.Type), apply: func(p *Plan) error {
+				return p.client.CustomFields().Create(&CustomField{Name: want.Name, Type: want.Type})
+			}})
+		} else if live.Type != want.Type {
+			plan.add(&Change{Action: ChangeReplace, Kind: "custom field", Name: want.Name, Detail: fmt.Sprintf("type %s => %s, existing values are lost", live.Type, want.Type), apply: func(p *Plan) error {
+				if err := p.client.CustomFields().Delete(live.ID); err != nil {
+					return err
+				}
+
+				return p.client.CustomFields().Create(&CustomField{Name: want.Name, Type: want.Type})
+			}})
+		}
+	}
+
+	if prune {
+		for _, field := range fields {
+			if !wantFields[field.Name] {
+				deletes = append(deletes, &Change{Action: ChangeDelete, Kind: "custom field", Name: field.Name, apply: func(p *Plan) error {
+					return p.client.CustomFields().Delete(field.ID)
+				}})
+			}
+		}
+	}
+
+	wantLists := make(map[string]bool)
+
+	for _, list := range lists {
+		plan.lists[list.Name] = list.ID
+	}
+
+	for _, want := range spec.Lists {
+		wantLists[want.Name] = true
+
+		if _, ok := plan.lists[want.Name]; !ok {
+			plan.add(&Change{Action: ChangeCreate, Kind: "list", Name: want.Name, apply: func(p *Plan) error {
+				list, err := p.client.Lists().Create(want.Name)
+
+				if err != nil {
+					return err
+				}
+
+				p.lists[list.Name] = list.ID
+
+				return nil
+			}})
+		}
+	}
+
+	var listDeletes []*Change
+
+	if prune {
+		for _, list := range lists {
+			if !wantLists[list.Name] {
+				listDeletes = append(listDeletes, &Change{Action: ChangeDelete, Kind: "list", Name: list.Name, apply: func(p *Plan) error {
+					return p.client.Lists().Delete(list.ID)
+				}})
+			}
+		}
+	}
+
+	liveSegments := make(map[string]*Segment)
+
+	for _, segment := range segments {
+		liveSegments[segment.Name] = segment
+	}
+
+	wantSegments := make(map[string]bool)
+
+	for _, want := range spec.Segments {
+		wantSegments[want.Name] = true
+
+		if want.List != "" && !wantLists[want.List] {
+			if _, ok := plan.lists[want.List]; !ok {
+				return nil, fmt.Errorf("contacts: segment %q refers to unknown list %q", want.Name, want.List)
+			}
+		}
+
+		live, ok := liveSegments[want.Name]
+
+		if !ok {
+			plan.add(&Change{Action: ChangeCreate, Kind: "segment", Name: want.Name, apply: func(p *Plan) error {
+				return p.client.Segments().Create(p.segment(want, 0))
+			}})
+		} else if detail := plan.segmentDiff(live, want); detail != "" {
+			plan.add(&Change{Action: ChangeUpdate, Kind: "segment", Name: want.Name, Detail: detail, apply: func(p *Plan) error {
+				return p.client.Segments().Update(p.segment(want, live.ID))
+			}})
+		}
+	}
+
+	if prune {
+		for _, segment := range segments {
+			if !wantSegments[segment.Name] {
+				plan.add(&Change{Action: ChangeDelete, Kind: "segment", Name: segment.Name, apply: func(p *Plan) error {
+					return p.client.Segments().Delete(segment.ID)
+				}})
+			}
+		}
+	}
+
+	// lists and custom fields are deleted last, after any segments which use them
+	for _, change := range append(listDeletes, deletes...) {
+		plan.add(change)
+	}
+
+	return plan, nil
+}
+
+func (p *Plan) add(change *Change) {
+	p.Changes = append(p.Changes, change)
+}
+
+func (p *Plan) segment(want SpecSegment, id uint) *Segment {
+	segment := &Segment{ID: id, Name: want.Name, Conditions: want.Conditions}
+
+	if want.List != "" {
+		segment.ListID = int(p.lists[want.List])
+	}
+
+	return segment
+}
+
+// segmentDiff describes how a live Segment differs from the Spec, or returns "" if it does not.
+func (p *Plan) segmentDiff(live *Segment, want SpecSegment) string {
+	var diffs []string
+
+	wantListID, ok := p.lists[want.List]
+
+	if want.List == "" {
+		wantListID, ok = 0, true
+	}
+
+	if !ok || uint(live.ListID) != wantListID {
+		diffs = append(diffs, "list")
+	}
+
+	if len(live.Conditions) != len(want.Conditions) {
+		diffs = append(diffs, "conditions")
+	} else {
+		for i := range live.Conditions {
+			if live.Conditions[i] != want.Conditions[i] {
+				diffs = append(diffs, "conditions")
+				break
+			}
+		}
+	}
+
+	sort.Strings(diffs)
+
+	if len(diffs) == 0 {
+		return ""
+	}
+
+	return strings.Join(diffs, ", ") + " changed"
+}
+
+// Apply makes the Plan's Changes in order, stopping at the first error. Applying a Plan and then planning
+// the same Spec again gives an empty Plan.
+func (p *Plan) Apply(ctx context.Context) error {
+	p.client = p.client.WithContext(ctx)
+
+	for _, change := range p.Changes {
+		if err := change.apply(p); err != nil {
+			return fmt.Errorf("contacts: %s %s %q: %w", change.Action, change.Kind, change.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package contacts
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testSpec = `
+custom_fields:
+  - name: pet
+    type: text
+lists:
+  - name: spec_list
+segments:
+  - name: spec_segment
+    list: spec_list
+    conditions:
+      - field: pet
+        value: dog
+        operator: eq
+        and_or: ""
+`
+
+func TestParseSpec(t *testing.T) {
+	spec, err := ParseSpec(strings.NewReader(testSpec))
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(spec.CustomFields) != 1 || len(spec.Lists) != 1 || len(spec.Segments) != 1 {
+		t.FailNow()
+	}
+
+	if spec.Segments[0].List != "spec_list" || spec.Segments[0].Conditions[0].Value != "dog" {
+		t.Fail()
+	}
+
+	spec, err = ParseSpec(strings.NewReader(`{"lists": [{"name": "json_list"}]}`))
+
+	if err != nil || len(spec.Lists) != 1 || spec.Lists[0].Name != "json_list" {
+		t.Error(err)
+	}
+
+	_, err = ParseSpec(strings.NewReader("lists:\n  - name: a\n  - name: a\n"))
+
+	if err == nil {
+		t.Fail()
+	}
+
+	_, err = ParseSpec(strings.NewReader("custom_fields:\n  - name: pet\n    type: txt\n"))
+
+	if err == nil || !strings.Contains(err.Error(), `"txt"`) {
+		t.Error(err)
+	}
+
+	for _, empty := range []string{"", "# nothing yet\n", "{}"} {
+		if _, err := ParseSpec(strings.NewReader(empty)); !errors.Is(err, ErrEmptySpec) {
+			t.Errorf("%q: %v", empty, err)
+		}
+	}
+
+	if _, err := client.PlanSpec(context.Background(), &Spec{}, true); !errors.Is(err, ErrEmptySpec) {
+		t.Error(err)
+	}
+}
+
+func TestPlan_String(t *testing.T) {
+	plan := &Plan{Changes: []*Change{
+		{Action: ChangeCreate, Kind: "list", Name: "a"},
+		{Action: ChangeDelete, Kind: "segment", Name: "b"},
+	}}
+
+	want := "+ create list \"a\"\n- delete segment \"b\"\n\nPlan: 1 to create, 0 to update, 0 to replace, 1 to delete.\n"
+
+	if plan.String() != want {
+		t.Error(plan.String())
+	}
+}
+
+func TestClient_PlanSpec(t *testing.T) {
+	spec, err := ParseSpec(strings.NewReader(testSpec))
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	plan, err := client.PlanSpec(context.Background(), spec, false)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if err := plan.Apply(context.Background()); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	plan, err = client.PlanSpec(context.Background(), spec, false)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if !plan.Empty() {
+		t.Error(plan.String())
+	}
+}
@@ -0,0 +1,208 @@
+package contacts
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"sort"
+	"strconv"
+)
+
+// ExportFormat is the file format written by an export.
+type ExportFormat int
+
+const (
+	// ExportCSV writes a CSV file with a header row.
+	ExportCSV ExportFormat = iota
+	// ExportNDJSON writes one JSON object per line.
+	ExportNDJSON
+)
+
+// reservedExportColumns are the columns written for every Recipient, before any custom fields.
+var reservedExportColumns = []string{"id", "email", "first_name", "last_name", "created_at"}
+
+// ExportList writes every Recipient on a List to w, with a column for each custom field.
+func (c *Client) ExportList(ctx context.Context, w io.Writer, format ExportFormat, listID uint) error {
+	c = c.WithContext(ctx)
+
+	return c.export(w, format, c.Lists().AllRecipients(listID))
+}
+
+// ExportSegment writes every Recipient on a Segment to w, with a column for each custom field.
+func (c *Client) ExportSegment(ctx context.Context, w io.Writer, format ExportFormat, segmentID uint) error {
+	c = c.WithContext(ctx)
+
+	return c.export(w, format, c.Segments().AllRecipients(segmentID))
+}
+
+// ExportAll writes every Recipient in the contactdb to w, with a column for each custom field.
+func (c *Client) ExportAll(ctx context.Context, w io.Writer, format ExportFormat) error {
+	c = c.WithContext(ctx)
+
+	return c.export(w, format, c.Recipients().All())
+}
+
+func (c *Client) export(w io.Writer, format ExportFormat, recipients iter.Seq2[*Recipient, error]) error {
+	fields, err := c.CustomFields().List()
+
+	if err != nil {
+		return err
+	}
+
+	var customColumns []string
+
+	for _, field := range fields {
+		customColumns = append(customColumns, field.Name)
+	}
+
+	sort.Strings(customColumns)
+
+	var rw recipientWriter
+
+	switch format {
+	case ExportCSV:
+		rw = newCSVRecipientWriter(w, customColumns)
+	case ExportNDJSON:
+		rw = newNDJSONRecipientWriter(w, customColumns)
+	default:
+		return fmt.Errorf("contacts: unknown export format: %d", format)
+	}
+
+	for recipient, err := range recipients {
+		if err != nil {
+			return err
+		}
+
+		if err := rw.Write(recipient); err != nil {
+			return err
+		}
+	}
+
+	return rw.Flush()
+}
+
+type recipientWriter interface {
+	Write(r *Recipient) error
+	Flush() error
+}
+
+func reservedValues(r *Recipient) []interface{} {
+	return []interface{}{r.ID, r.Email, r.FirstName, r.LastName, r.CreatedAt}
+}
+
+type csvRecipientWriter struct {
+	w             *csv.Writer
+	customColumns []string
+	wroteHeader   bool
+}
+
+func newCSVRecipientWriter(w io.Writer, customColumns []string) *csvRecipientWriter {
+	return &csvRecipientWriter{w: csv.NewWriter(w), customColumns: customColumns}
+}
+
+func (x *csvRecipientWriter) writeHeader() error {
+	x.wroteHeader = true
+
+	return x.w.Write(append(append([]string{}, reservedExportColumns...), x.customColumns...))
+}
+
+func (x *csvRecipientWriter) Write(r *Recipient) error {
+	if !x.wroteHeader {
+		if err := x.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	var record []string
+
+	for _, value := range reservedValues(r) {
+		record = append(record, formatCSVValue(value))
+	}
+
+	for _, name := range x.customColumns {
+		record = append(record, formatCSVValue(r.CustomField(name)))
+	}
+
+	return x.w.Write(record)
+}
+
+func (x *csvRecipientWriter) Flush() error {
+	if !x.wroteHeader {
+		if err := x.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	x.w.Flush()
+
+	return x.w.Error()
+}
+
+func formatCSVValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+type ndjsonRecipientWriter struct {
+	w             *bufio.Writer
+	customColumns []string
+}
+
+func newNDJSONRecipientWriter(w io.Writer, customColumns []string) *ndjsonRecipientWriter {
+	return &ndjsonRecipientWriter{w: bufio.NewWriter(w), customColumns: customColumns}
+}
+
+// Write writes the Recipient as a JSON object whose keys are in column order, which encoding/json
+// does not preserve for maps.
+func (x *ndjsonRecipientWriter) Write(r *Recipient) error {
+	x.w.WriteByte('{')
+
+	columns := append(append([]string{}, reservedExportColumns...), x.customColumns...)
+	values := reservedValues(r)
+
+	for _, name := range x.customColumns {
+		values = append(values, r.CustomField(name))
+	}
+
+	for i, column := range columns {
+		if i > 0 {
+			x.w.WriteByte(',')
+		}
+
+		key, err := json.Marshal(column)
+
+		if err != nil {
+			return err
+		}
+
+		value, err := json.Marshal(values[i])
+
+		if err != nil {
+			return err
+		}
+
+		x.w.Write(key)
+		x.w.WriteByte(':')
+		x.w.Write(value)
+	}
+
+	_, err := x.w.WriteString("}\n")
+
+	return err
+}
+
+func (x *ndjsonRecipientWriter) Flush() error {
+	return x.w.Flush()
+}
@@ -0,0 +1,97 @@
+package contacts
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+var exportRecipient = &Recipient{
+	ID:        "abc",
+	Email:     "export@example.com",
+	FirstName: "Ex",
+	LastName:  "Port",
+	CreatedAt: 1500000000,
+	CustomFields: []CustomField{
+		{Name: "pet", Value: "dog"},
+		{Name: "age", Value: float64(7)},
+	},
+}
+
+func TestCSVRecipientWriter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := newCSVRecipientWriter(buf, []string{"age", "beer", "pet"})
+
+	if err := w.Write(exportRecipient); err != nil {
+		t.Error(err)
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Error(err)
+	}
+
+	want := "id,email,first_name,last_name,created_at,age,beer,pet\n" +
+		"abc,export@example.com,Ex,Port,1500000000,7,,dog\n"
+
+	if buf.String() != want {
+		t.Error(buf.String())
+	}
+}
+
+func TestNDJSONRecipientWriter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := newNDJSONRecipientWriter(buf, []string{"age", "beer", "pet"})
+
+	if err := w.Write(exportRecipient); err != nil {
+		t.Error(err)
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Error(err)
+	}
+
+	want := `{"id":"abc","email":"export@example.com","first_name":"Ex","last_name":"Port","created_at":1500000000,"age":7,"beer":null,"pet":"dog"}` + "\n"
+
+	if buf.String() != want {
+		t.Error(buf.String())
+	}
+}
+
+func TestClient_ExportList(t *testing.T) {
+	r := &Recipient{Email: "export.list@example.com"}
+
+	_, err := client.Recipients().Add(r)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	list, err := client.Lists().Create("export_list")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	err = client.Lists().AddRecipients(list.ID, r)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	buf := new(bytes.Buffer)
+
+	err = client.ExportList(context.Background(), buf, ExportCSV, list.ID)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if !strings.HasPrefix(buf.String(), "id,email,first_name,last_name,created_at") {
+		t.Fail()
+	}
+}
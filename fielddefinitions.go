@@ -0,0 +1,122 @@
+package contacts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// FieldType is the type of a custom field, shared by the legacy CustomField and the new API's
+// FieldDefinition. Its values are the legacy lowercase names; the new API's capitalised names are
+// normalised to them when decoded.
+type FieldType string
+
+// Types of custom field.
+const (
+	FieldTypeText   FieldType = "text"
+	FieldTypeNumber FieldType = "number"
+	FieldTypeDate   FieldType = "date"
+)
+
+// UnmarshalText normalises both the legacy and new API names of a FieldType, returning an error for any
+// other value.
+func (t *FieldType) UnmarshalText(b []byte) error {
+	switch fieldType := FieldType(strings.ToLower(string(b))); fieldType {
+	case FieldTypeText, FieldTypeNumber, FieldTypeDate:
+		*t = fieldType
+		return nil
+	default:
+		return fmt.Errorf("contacts: unknown field type %q", b)
+	}
+}
+
+// definitionName is the name of the FieldType in the new API, e.g. "Text".
+func (t FieldType) definitionName() string {
+	if t == "" {
+		return ""
+	}
+
+	return strings.ToUpper(string(t[:1])) + string(t[1:])
+}
+
+// FieldDefinition is a field of Contacts in the new Marketing Campaigns API. Custom fields have IDs such as
+// "e1_T", which key Contact.CustomFields; reserved fields, such as first_name, are read only.
+type FieldDefinition struct {
+	ID        string    `json:"id,omitempty"`
+	Name      string    `json:"name"`
+	FieldType FieldType `json:"field_type"`
+	ReadOnly  bool      `json:"read_only,omitempty"`
+}
+
+// FieldDefinitionsClient provides methods for managing FieldDefinitions in the new Marketing Campaigns API.
+type FieldDefinitionsClient struct {
+	client *Client
+}
+
+type createFieldDefinitionRequest struct {
+	Name      string `json:"name"`
+	FieldType string `json:"field_type"`
+}
+
+// Create a custom Field Definition
+//
+// https://docs.sendgrid.com/api-reference/custom-fields/create-custom-field-definition
+func (c *FieldDefinitionsClient) Create(ctx context.Context, name string, fieldType FieldType) (*FieldDefinition, error) {
+	var field *FieldDefinition
+
+	err := c.client.makeRequest(ctx, "FieldDefinitionsClient.Create", http.MethodPost, "/marketing/field_definitions", createFieldDefinitionRequest{Name: name, FieldType: fieldType.definitionName()}, &field)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return field, nil
+}
+
+type fieldDefinitionsResponse struct {
+	CustomFields   []*FieldDefinition `json:"custom_fields"`
+	ReservedFields []*FieldDefinition `json:"reserved_fields"`
+}
+
+// List all Field Definitions, returning the custom and reserved fields separately.
+//
+// https://docs.sendgrid.com/api-reference/custom-fields/get-all-field-definitions
+func (c *FieldDefinitionsClient) List(ctx context.Context) (custom, reserved []*FieldDefinition, err error) {
+	var resp *fieldDefinitionsResponse
+
+	err = c.client.makeRequest(ctx, "FieldDefinitionsClient.List", http.MethodGet, "/marketing/field_definitions", nil, &resp)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.CustomFields, resp.ReservedFields, nil
+}
+
+type updateFieldDefinitionRequest struct {
+	Name string `json:"name"`
+}
+
+// UpdateName renames a custom Field Definition. Its type cannot be changed.
+//
+// https://docs.sendgrid.com/api-reference/custom-fields/update-custom-field-definition
+func (c *FieldDefinitionsClient) UpdateName(ctx context.Context, fieldID, name string) (*FieldDefinition, error) {
+	var field *FieldDefinition
+
+	err := c.client.makeRequest(ctx, "FieldDefinitionsClient.UpdateName", http.MethodPatch, "/marketing/field_definitions/"+url.PathEscape(fieldID), updateFieldDefinitionRequest{Name: name}, &field)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return field, nil
+}
+
+// Delete a custom Field Definition
+//
+// https://docs.sendgrid.com/api-reference/custom-fields/delete-custom-field-definition
+func (c *FieldDefinitionsClient) Delete(ctx context.Context, fieldID string) error {
+	return c.client.makeRequest(ctx, "FieldDefinitionsClient.Delete", http.MethodDelete, "/marketing/field_definitions/"+url.PathEscape(fieldID), nil, nil)
+}
@@ -0,0 +1,70 @@
+package contacts
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFieldType_UnmarshalText(t *testing.T) {
+	var field FieldDefinition
+
+	err := json.Unmarshal([]byte(`{"id":"e1_N","name":"age","field_type":"Number"}`), &field)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if field.FieldType != FieldTypeNumber || field.FieldType.definitionName() != "Number" {
+		t.Fail()
+	}
+
+	err = json.Unmarshal([]byte(`{"id":"e2_T","name":"pet","field_type":"Txt"}`), &field)
+
+	if err == nil || !strings.Contains(err.Error(), `"Txt"`) {
+		t.Error(err)
+	}
+}
+
+func TestFieldDefinitionsClient(t *testing.T) {
+	ctx := context.Background()
+
+	field, err := client.FieldDefinitions().Create(ctx, "favourite_cheese", FieldTypeText)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	defer client.FieldDefinitions().Delete(ctx, field.ID)
+
+	renamed, err := client.FieldDefinitions().UpdateName(ctx, field.ID, "favorite_cheese")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if renamed.Name != "favorite_cheese" || renamed.FieldType != FieldTypeText {
+		t.Fail()
+	}
+
+	custom, reserved, err := client.FieldDefinitions().List(ctx)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	found := false
+
+	for _, f := range custom {
+		found = found || f.ID == field.ID
+	}
+
+	if !found || len(reserved) == 0 {
+		t.Fail()
+	}
+}
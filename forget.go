@@ -0,0 +1,202 @@
+package contacts
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Outcomes of an ErasureStep.
+const (
+	ErasureDone     = "done"
+	ErasureNotFound = "not_found"
+	ErasureFailed   = "failed"
+)
+
+// ErasureStep is a single action taken by Forget.
+type ErasureStep struct {
+	Action  string    `json:"action"`
+	Target  string    `json:"target,omitempty"`
+	Outcome string    `json:"outcome"`
+	Error   string    `json:"error,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+// ErrMissingSigningKey is returned by Forget when no ForgetOptions.SigningKey is given.
+var ErrMissingSigningKey = errors.New("contacts: a signing key is required to forget a contact")
+
+// ErasureRecord is an audit record of a Forget. It identifies the contact by an HMAC-SHA256 of their
+// normalised email address keyed with the signing key, so the record does not itself hold the personal
+// data which was erased, and candidate addresses can't be hashed to find it without the key. Only someone
+// holding the key can check which address a record is for, with MatchesEmail.
+type ErasureRecord struct {
+	EmailHash   string        `json:"email_hash"`
+	StartedAt   time.Time     `json:"started_at"`
+	CompletedAt time.Time     `json:"completed_at"`
+	Steps       []ErasureStep `json:"steps"`
+
+	// Signature is the hex HMAC-SHA256 of the record with an empty Signature.
+	Signature string `json:"signature,omitempty"`
+}
+
+// emailHash is the hex HMAC-SHA256 of a normalised email address.
+func emailHash(key []byte, email string) string {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(strings.ToLower(strings.TrimSpace(email))))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// MatchesEmail reports whether the record is for the given email address, hashed with key.
+func (r *ErasureRecord) MatchesEmail(key []byte, email string) bool {
+	return hmac.Equal([]byte(r.EmailHash), []byte(emailHash(key, email)))
+}
+
+func (r *ErasureRecord) mac(key []byte) []byte {
+	unsigned := *r
+	unsigned.Signature = ""
+
+	b, _ := json.Marshal(unsigned)
+
+	h := hmac.New(sha256.New, key)
+	h.Write(b)
+
+	return h.Sum(nil)
+}
+
+// Verify reports whether the record is signed with key and has not been modified since.
+func (r *ErasureRecord) Verify(key []byte) bool {
+	signature, err := hex.DecodeString(r.Signature)
+
+	return err == nil && hmac.Equal(signature, r.mac(key))
+}
+
+func (r *ErasureRecord) step(action, target string, err error) error {
+	step := ErasureStep{Action: action, Target: target, Outcome: ErasureDone, At: time.Now().UTC()}
+
+	if IsNotFound(err) {
+		step.Outcome = ErasureNotFound
+		err = nil
+	} else if err != nil {
+		step.Outcome = ErasureFailed
+		step.Error = err.Error()
+	}
+
+	r.Steps = append(r.Steps, step)
+
+	return err
+}
+
+// ForgetOptions configure Client.Forget.
+type ForgetOptions struct {
+	// Suppress adds the email address to the global suppression list, so that it isn't emailed again if it
+	// is re-imported.
+	Suppress bool
+
+	// SigningKey is required, and is used to hash the email address in the ErasureRecord and to sign it.
+	// Keep it secret: anyone holding it can check which address a record is for.
+	SigningKey []byte
+}
+
+// Forget erases a contact for a right-to-erasure request: the Recipient is found by email address, removed
+// from every List, and deleted, then optionally added to the global suppression list. Each step and its
+// outcome is recorded in the returned ErasureRecord, which is returned even if a step fails. A signing key
+// is required, returning ErrMissingSigningKey without erasing anything if there is none.
+func (c *Client) Forget(ctx context.Context, email string, opts *ForgetOptions) (*ErasureRecord, error) {
+	if opts == nil || len(opts.SigningKey) == 0 {
+		return nil, ErrMissingSigningKey
+	}
+
+	c = c.WithContext(ctx)
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	record := &ErasureRecord{EmailHash: emailHash(opts.SigningKey, email), StartedAt: time.Now().UTC()}
+
+	err := c.forget(ctx, email, opts, record)
+
+	record.CompletedAt = time.Now().UTC()
+	record.Signature = hex.EncodeToString(record.mac(opts.SigningKey))
+
+	return record, err
+}
+
+func (c *Client) forget(ctx context.Context, email string, opts *ForgetOptions, record *ErasureRecord) error {
+	recipient, err := c.findRecipient(email)
+
+	if err := record.step("find_recipient", "", err); err != nil {
+		return err
+	}
+
+	if recipient != nil {
+		lists, err := c.Recipients().ListsForRecipient(recipient.ID)
+
+		if err := record.step("find_lists", "", err); err != nil {
+			return err
+		}
+
+		for _, list := range lists {
+			err := c.Lists().DeleteRecipientByID(list.ID, recipient.ID)
+
+			if err := record.step("remove_from_list", strconv.FormatUint(uint64(list.ID), 10), err); err != nil {
+				return err
+			}
+		}
+
+		err = c.Recipients().Delete([]string{recipient.ID})
+
+		if err := record.step("delete_recipient", "", err); err != nil {
+			return err
+		}
+	}
+
+	if opts.Suppress {
+		err := c.AddGlobalSuppressions(ctx, email)
+
+		if err := record.step("suppress", "", err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findRecipient finds a Recipient by email address, by its ID and then by searching.
+func (c *Client) findRecipient(email string) (*Recipient, error) {
+	recipient, err := c.Recipients().Get(ToRecipientID(email))
+
+	if !IsNotFound(err) {
+		return recipient, err
+	}
+
+	recipients, err := c.Recipients().Search(SearchTerm{FieldName: "email", FieldValue: email})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, recipient := range recipients {
+		if strings.EqualFold(recipient.Email, email) {
+			return recipient, nil
+		}
+	}
+
+	return nil, &APIError{StatusCode: http.StatusNotFound}
+}
+
+type globalSuppressions struct {
+	RecipientEmails []string `json:"recipient_emails"`
+}
+
+// AddGlobalSuppressions adds email addresses to the global suppression list, so that they are never emailed.
+//
+// https://sendgrid.com/docs/API_Reference/Web_API_v3/Suppression_Management/global_suppressions.html
+func (c *Client) AddGlobalSuppressions(ctx context.Context, emails ...string) error {
+	return c.makeRequest(ctx, "Client.AddGlobalSuppressions", http.MethodPost, "/asm/suppressions/global", globalSuppressions{RecipientEmails: emails}, nil)
+}
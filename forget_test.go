@@ -0,0 +1,95 @@
+package contacts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestErasureRecord_Verify(t *testing.T) {
+	key := []byte("secret")
+
+	record := &ErasureRecord{EmailHash: "abc", Steps: []ErasureStep{{Action: "delete_recipient", Outcome: ErasureDone}}}
+	record.Signature = "00"
+
+	if record.Verify(key) {
+		t.Fail()
+	}
+
+	record.Signature = hex.EncodeToString(record.mac(key))
+
+	if !record.Verify(key) || record.Verify([]byte("other")) {
+		t.Fail()
+	}
+
+	record.Steps[0].Outcome = ErasureFailed
+
+	if record.Verify(key) {
+		t.Fail()
+	}
+}
+
+func TestErasureRecord_MatchesEmail(t *testing.T) {
+	key := []byte("secret")
+
+	record := &ErasureRecord{EmailHash: emailHash(key, "john@example.com")}
+
+	if !record.MatchesEmail(key, " John@Example.com") || record.MatchesEmail([]byte("other"), "john@example.com") || record.MatchesEmail(key, "jane@example.com") {
+		t.Fail()
+	}
+
+	// an unkeyed hash of the address can't be used to find the record
+	unkeyed := sha256.Sum256([]byte("john@example.com"))
+
+	if record.EmailHash == hex.EncodeToString(unkeyed[:]) {
+		t.Fail()
+	}
+}
+
+func TestClient_Forget_missingSigningKey(t *testing.T) {
+	transport := &headerRecordingTransport{}
+
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: transport}
+
+	for _, opts := range []*ForgetOptions{nil, {Suppress: true}} {
+		record, err := c.Forget(context.Background(), "john@example.com", opts)
+
+		if !errors.Is(err, ErrMissingSigningKey) || record != nil || transport.url != nil {
+			t.Error(record, err)
+		}
+	}
+}
+
+func TestClient_Forget(t *testing.T) {
+	r := &Recipient{FirstName: "Forget", LastName: "Me", Email: "forget.me@example.com"}
+
+	_, err := client.Recipients().Add(r)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	key := []byte("secret")
+
+	record, err := client.Forget(context.Background(), "Forget.Me@example.com", &ForgetOptions{SigningKey: key})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if !record.Verify(key) || !record.MatchesEmail(key, r.Email) || len(record.Steps) == 0 || record.Steps[len(record.Steps)-1].Action != "delete_recipient" {
+		t.Fail()
+	}
+
+	_, err = client.Recipients().Get(r.ID)
+
+	if !IsNotFound(err) {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,214 @@
+package contacts
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultPollInterval    = time.Second
+	defaultMaxPollInterval = 30 * time.Second
+)
+
+// Statuses of a Job.
+const (
+	JobPending   = "pending"
+	JobCompleted = "completed"
+	JobErrored   = "errored"
+	JobFailed    = "failed"
+)
+
+// Job is an asynchronous contacts job in the new Marketing Campaigns API, such as an upsert or import.
+type Job struct {
+	ID string
+
+	// PollInterval is how long Wait waits before first checking the Job's status. The interval doubles after
+	// each check, up to MaxPollInterval. They default to 1 and 30 seconds.
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+
+	client *Client
+}
+
+func newJob(c *Client, id string) *Job {
+	return &Job{ID: id, PollInterval: defaultPollInterval, MaxPollInterval: defaultMaxPollInterval, client: c}
+}
+
+// JobStatus is the status of a Job.
+type JobStatus struct {
+	ID         string     `json:"id"`
+	Status     string     `json:"status"`
+	JobType    string     `json:"job_type"`
+	Results    JobResults `json:"results"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+}
+
+// Done reports whether the Job has finished, successfully or not.
+func (s *JobStatus) Done() bool {
+	return s.Status != JobPending
+}
+
+// JobResults are the counts of Contacts a Job has processed.
+type JobResults struct {
+	RequestedCount int    `json:"requested_count"`
+	CreatedCount   int    `json:"created_count"`
+	UpdatedCount   int    `json:"updated_count"`
+	DeletedCount   int    `json:"deleted_count"`
+	ErroredCount   int    `json:"errored_count"`
+	ErrorsURL      string `json:"errors_url"`
+}
+
+// JobResult is the outcome of a finished Job.
+type JobResult struct {
+	*JobStatus
+
+	// Errors is the contents of the file at Results.ErrorsURL, describing each Contact which errored.
+	Errors []byte
+}
+
+// Status gets the current status of the Job.
+//
+// https://docs.sendgrid.com/api-reference/contacts/import-contacts-status
+func (j *Job) Status(ctx context.Context) (*JobStatus, error) {
+	var status *JobStatus
+
+	err := j.client.makeRequest(ctx, "Job.Status", http.MethodGet, "/marketing/contacts/imports/"+url.PathEscape(j.ID), nil, &status)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// Wait polls the Job's status with exponential backoff until it finishes or ctx is done. If any Contacts
+// errored, the errors file is downloaded into the result. A Job which failed outright returns an error
+// along with its result.
+func (j *Job) Wait(ctx context.Context) (*JobResult, error) {
+	var status *JobStatus
+
+	err := poll(ctx, j.PollInterval, j.MaxPollInterval, func() (bool, error) {
+		var err error
+
+		status, err = j.Status(ctx)
+
+		return err == nil && status.Done(), err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &JobResult{JobStatus: status}
+
+	if status.Results.ErrorsURL != "" {
+		result.Errors, err = j.client.download(ctx, status.Results.ErrorsURL)
+
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if status.Status == JobFailed {
+		return result, fmt.Errorf("contacts: job %s failed", j.ID)
+	}
+
+	return result, nil
+}
+
+// poll calls check, waiting interval before the first call and doubling it after each call up to max,
+// until check reports that it is done, returns an error, or ctx is done.
+func poll(ctx context.Context, interval, max time.Duration, check func() (bool, error)) error {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	if max < interval {
+		max = interval
+	}
+
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		done, err := check()
+
+		if err != nil || done {
+			return err
+		}
+
+		interval = min(interval*2, max)
+		timer.Reset(interval)
+	}
+}
+
+// download gets a file from a pre-signed URL, which must not be sent the API key.
+func (c *Client) download(ctx context.Context, fileURL string) ([]byte, error) {
+	body, err := c.openDownload(ctx, fileURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer body.Close()
+
+	return ioutil.ReadAll(body)
+}
+
+// openDownload opens a file from a pre-signed URL, decompressing it if it is gzipped.
+func (c *Client) openDownload(ctx context.Context, fileURL string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+
+		b, _ := ioutil.ReadAll(resp.Body)
+
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(b)}
+	}
+
+	br := bufio.NewReader(resp.Body)
+
+	// exported files are gzipped without a Content-Encoding header, so check the content itself
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+
+		return readCloser{Reader: zr, Closer: resp.Body}, nil
+	}
+
+	return readCloser{Reader: br, Closer: resp.Body}, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,124 @@
+package contacts
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPoll(t *testing.T) {
+	calls := 0
+
+	err := poll(context.Background(), time.Millisecond, 4*time.Millisecond, func() (bool, error) {
+		calls++
+		return calls == 3, nil
+	})
+
+	if err != nil || calls != 3 {
+		t.Error(err, calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = poll(ctx, time.Millisecond, time.Millisecond, func() (bool, error) {
+		return false, nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Error(err)
+	}
+}
+
+// jobTransport fakes a Job which is pending until it has been checked twice, then finishes with status,
+// and serves its errors file, gzipped if gzipped is set.
+type jobTransport struct {
+	status  string
+	gzipped bool
+	checks  int
+}
+
+func (x *jobTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	body := `{}`
+
+	switch {
+	case r.URL.Path == "/v3/marketing/contacts/imports/job-id":
+		x.checks++
+
+		if x.checks < 2 {
+			body = `{"id": "job-id", "status": "pending"}`
+		} else {
+			body = fmt.Sprintf(`{"id": "job-id", "status": %q, "job_type": "upsert", "results": {"requested_count": 2, "created_count": 1, "errored_count": 1, "errors_url": "https://files.example.com/errors"}, "finished_at": "2026-10-19T09:00:00Z"}`, x.status)
+		}
+	case r.URL.Host == "files.example.com":
+		if r.Header.Get("Authorization") != "" {
+			return nil, errors.New("api key sent to errors file")
+		}
+
+		body = "email,error\nbad,invalid email\n"
+
+		if x.gzipped {
+			var b bytes.Buffer
+
+			zw := gzip.NewWriter(&b)
+			zw.Write([]byte(body))
+			zw.Close()
+
+			body = b.String()
+		}
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+func TestJob_Wait(t *testing.T) {
+	tests := []struct {
+		status  string
+		gzipped bool
+		failed  bool
+	}{
+		{JobCompleted, true, false},
+		{JobErrored, false, false},
+		{JobFailed, false, true},
+	}
+
+	for _, test := range tests {
+		transport := &jobTransport{status: test.status, gzipped: test.gzipped}
+
+		c := New("apikey")
+		c.HTTPClient = &http.Client{Transport: transport}
+
+		job := newJob(c, "job-id")
+		job.PollInterval, job.MaxPollInterval = time.Millisecond, time.Millisecond
+
+		result, err := job.Wait(context.Background())
+
+		if (err != nil) != test.failed || result == nil {
+			t.Error(test.status, err)
+			t.FailNow()
+		}
+
+		if transport.checks != 2 || result.Status != test.status || result.JobType != "upsert" || result.FinishedAt == nil {
+			t.Error(test.status, transport.checks, result.JobStatus)
+		}
+
+		if result.Results.RequestedCount != 2 || result.Results.CreatedCount != 1 || result.Results.ErroredCount != 1 {
+			t.Error(test.status, result.Results)
+		}
+
+		if string(result.Errors) != "email,error\nbad,invalid email\n" {
+			t.Errorf("%s: %q", test.status, result.Errors)
+		}
+	}
+}
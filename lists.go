@@ -2,6 +2,7 @@ package contacts
 
 import (
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -23,7 +24,7 @@ type ListsClient struct {
 func (c *ListsClient) Create(name string) (*List, error) {
 	list := &List{Name: name}
 
-	err := c.client.makeRequest(http.MethodPost, "/contactdb/lists", list, &list)
+	err := c.client.makeRequest(c.client.context(), "ListsClient.Create", http.MethodPost, "/contactdb/lists", list, &list)
 
 	if err != nil {
 		return nil, err
@@ -42,7 +43,7 @@ type listListsResponse struct {
 func (c *ListsClient) List() ([]*List, error) {
 	var resp *listListsResponse
 
-	err := c.client.makeRequest(http.MethodGet, "/contactdb/lists", nil, &resp)
+	err := c.client.makeRequest(c.client.context(), "ListsClient.List", http.MethodGet, "/contactdb/lists", nil, &resp)
 
 	if err != nil {
 		return nil, err
@@ -55,7 +56,7 @@ func (c *ListsClient) List() ([]*List, error) {
 //
 // https://sendgrid.com/docs/API_Reference/Web_API_v3/Marketing_Campaigns/contactdb.html#Delete-Multiple-lists-DELETE
 func (c *ListsClient) Delete(listIDs ...uint) error {
-	return c.client.makeRequest(http.MethodDelete, "/contactdb/lists", listIDs, nil)
+	return c.client.makeRequest(c.client.context(), "ListsClient.Delete", http.MethodDelete, "/contactdb/lists", listIDs, nil)
 }
 
 // Get (Retrieve) a List
@@ -64,7 +65,7 @@ func (c *ListsClient) Delete(listIDs ...uint) error {
 func (c *ListsClient) Get(listID uint) (*List, error) {
 	var list *List
 
-	err := c.client.makeRequest(http.MethodGet, fmt.Sprintf("/contactdb/lists/%d", listID), nil, &list)
+	err := c.client.makeRequest(c.client.context(), "ListsClient.Get", http.MethodGet, fmt.Sprintf("/contactdb/lists/%d", listID), nil, &list)
 
 	if err != nil {
 		return nil, err
@@ -77,16 +78,16 @@ func (c *ListsClient) Get(listID uint) (*List, error) {
 //
 // https://sendgrid.com/docs/API_Reference/Web_API_v3/Marketing_Campaigns/contactdb.html#Update-a-List-PATCH
 func (c *ListsClient) Update(list *List) error {
-	return c.client.makeRequest(http.MethodPatch, fmt.Sprintf("/contactdb/lists/%d", list.ID), list, nil)
+	return c.client.makeRequest(c.client.context(), "ListsClient.Update", http.MethodPatch, fmt.Sprintf("/contactdb/lists/%d", list.ID), list, nil)
 }
 
 // ListRecipients on a given List
 //
 // https://sendgrid.com/docs/API_Reference/Web_API_v3/Marketing_Campaigns/contactdb.html#List-Recipients-on-a-List-GET
 func (c *ListsClient) ListRecipients(listID, pageSize, pageNum uint) ([]*Recipient, error) {
-	var resp *listRecipientsResponse
+	var resp listRecipientsResponse
 
-	err := c.client.makeRequest(http.MethodGet, fmt.Sprintf("/contactdb/lists/%d/recipients?page_size=%d&page=%d", listID, pageSize, pageNum), nil, &resp)
+	err := c.client.makeRequest(c.client.context(), "ListsClient.ListRecipients", http.MethodGet, fmt.Sprintf("/contactdb/lists/%d/recipients?page_size=%d&page=%d", listID, pageSize, pageNum), nil, &resp)
 
 	if err != nil {
 		return nil, err
@@ -95,6 +96,22 @@ func (c *ListsClient) ListRecipients(listID, pageSize, pageNum uint) ([]*Recipie
 	return resp.Recipients, nil
 }
 
+// ListRecipientsFunc calls fn with each Recipient on a page of a List as it is read from the response.
+// If fn returns an error, ListRecipientsFunc stops and returns it.
+//
+// https://sendgrid.com/docs/API_Reference/Web_API_v3/Marketing_Campaigns/contactdb.html#List-Recipients-on-a-List-GET
+func (c *ListsClient) ListRecipientsFunc(listID, pageSize, pageNum uint, fn func(*Recipient) error) error {
+	return c.client.makeRequest(c.client.context(), "ListsClient.ListRecipients", http.MethodGet, fmt.Sprintf("/contactdb/lists/%d/recipients?page_size=%d&page=%d", listID, pageSize, pageNum), nil, &listRecipientsResponse{each: fn})
+}
+
+// AllRecipients iterates over every Recipient on a List, fetching them a page at a time. Iteration stops
+// at the first error.
+func (c *ListsClient) AllRecipients(listID uint) iter.Seq2[*Recipient, error] {
+	return pageRecipients(func(page uint, fn func(*Recipient) error) error {
+		return c.ListRecipientsFunc(listID, maxPageSize, page, fn)
+	})
+}
+
 // AddRecipients to a List
 //
 // https://sendgrid.com/docs/API_Reference/Web_API_v3/Marketing_Campaigns/contactdb.html#Add-Multiple-Recipients-to-a-List-POST
@@ -112,7 +129,7 @@ func (c *ListsClient) AddRecipients(listID uint, recipients ...*Recipient) error
 //
 // https://sendgrid.com/docs/API_Reference/Web_API_v3/Marketing_Campaigns/contactdb.html#Add-Multiple-Recipients-to-a-List-POST
 func (c *ListsClient) AddRecipientsByIDs(listID uint, recipientIDs ...string) error {
-	return c.client.makeRequest(http.MethodPost, fmt.Sprintf("/contactdb/lists/%d/recipients", listID), recipientIDs, nil)
+	return c.client.makeRequest(c.client.context(), "ListsClient.AddRecipientsByIDs", http.MethodPost, fmt.Sprintf("/contactdb/lists/%d/recipients", listID), recipientIDs, nil)
 }
 
 // DeleteRecipient from a List
@@ -126,5 +143,116 @@ func (c *ListsClient) DeleteRecipient(listID uint, recipient *Recipient) error {
 //
 // https://sendgrid.com/docs/API_Reference/Web_API_v3/Marketing_Campaigns/contactdb.html#Delete-a-Single-Recipient-from-a-Single-List-DELETE
 func (c *ListsClient) DeleteRecipientByID(listID uint, recipientID string) error {
-	return c.client.makeRequest(http.MethodDelete, fmt.Sprintf("/contactdb/lists/%d/recipients/%s", listID, recipientID), nil, nil)
+	return c.client.makeRequest(c.client.context(), "ListsClient.DeleteRecipientByID", http.MethodDelete, fmt.Sprintf("/contactdb/lists/%d/recipients/%s", listID, recipientID), nil, nil)
+}
+
+// DeleteRecipients removes multiple Recipients from a List, returning the IDs of those which were not members
+// of it. Membership is checked with whichever takes fewer requests: looking up the Lists of each given
+// Recipient, or paging through the List 1000 Recipients at a time. Removing a few Recipients from a large List
+// therefore costs one request per Recipient, and many Recipients one request per page of the List. The
+// members are then removed in batches of 1000.
+//
+// https://sendgrid.com/docs/API_Reference/Web_API_v3/Marketing_Campaigns/contactdb.html#Delete-Multiple-Recipients-from-a-Single-List-DELETE
+func (c *ListsClient) DeleteRecipients(listID uint, recipientIDs ...string) (notMembers []string, err error) {
+	requested := make(map[string]bool, len(recipientIDs))
+
+	for _, id := range recipientIDs {
+		requested[id] = true
+	}
+
+	if len(requested) == 0 {
+		return nil, nil
+	}
+
+	list, err := c.Get(listID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pages := (list.RecipientCount + maxPageSize - 1) / maxPageSize
+
+	var members []string
+
+	if len(requested) < pages {
+		members, err = c.membersByRecipient(listID, requested)
+	} else {
+		members, err = c.membersByPage(listID, requested)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range members {
+		delete(requested, id)
+	}
+
+	for _, id := range recipientIDs {
+		if requested[id] {
+			notMembers = append(notMembers, id)
+			delete(requested, id)
+		}
+	}
+
+	return notMembers, c.deleteRecipients(listID, members)
+}
+
+// membersByRecipient returns which of the requested Recipients are members of a List by looking up the
+// Lists of each one. Recipients which do not exist are not members.
+func (c *ListsClient) membersByRecipient(listID uint, requested map[string]bool) ([]string, error) {
+	var members []string
+
+	recipients := c.client.Recipients()
+
+	for id := range requested {
+		lists, err := recipients.ListsForRecipient(id)
+
+		if IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		for _, list := range lists {
+			if list.ID == listID {
+				members = append(members, id)
+				break
+			}
+		}
+	}
+
+	return members, nil
+}
+
+// membersByPage returns which of the requested Recipients are members of a List by paging through it.
+func (c *ListsClient) membersByPage(listID uint, requested map[string]bool) ([]string, error) {
+	var members []string
+
+	for recipient, err := range c.AllRecipients(listID) {
+		if err != nil {
+			return nil, err
+		}
+
+		if requested[recipient.ID] {
+			members = append(members, recipient.ID)
+		}
+	}
+
+	return members, nil
+}
+
+// deleteRecipients removes Recipients which are known to be members of a List, in batches.
+func (c *ListsClient) deleteRecipients(listID uint, recipientIDs []string) error {
+	for start := 0; start < len(recipientIDs); start += maxBatchSize {
+		end := min(start+maxBatchSize, len(recipientIDs))
+
+		err := c.client.makeRequest(c.client.context(), "ListsClient.DeleteRecipients", http.MethodDelete, fmt.Sprintf("/contactdb/lists/%d/recipients", listID), recipientIDs[start:end], nil)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
@@ -1,6 +1,13 @@
 package contacts
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -186,3 +193,134 @@ func TestListsClient_Recipients(t *testing.T) {
 		}
 	}
 }
+
+func TestListsClient_DeleteRecipients(t *testing.T) {
+	r1 := &Recipient{Email: "bulk.delete.one@example.com"}
+	r2 := &Recipient{Email: "bulk.delete.two@example.com"}
+
+	_, err := client.Recipients().Add(r1, r2)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	list, err := client.Lists().Create("bulk_delete")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	err = client.Lists().AddRecipients(list.ID, r1)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	// adding recipients to lists takes some time it seems...
+	time.Sleep(time.Second * 5)
+
+	notMembers, err := client.Lists().DeleteRecipients(list.ID, r1.ID, r2.ID)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(notMembers) != 1 || notMembers[0] != r2.ID {
+		t.Fail()
+	}
+}
+
+// listTransport fakes the contactdb endpoints for a single List with the given members, recording the size of
+// each batch of Recipients removed from it.
+type listTransport struct {
+	listID   uint
+	members  []string
+	requests int
+	removed  []int
+}
+
+func (x *listTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	x.requests++
+
+	listPath := fmt.Sprintf("/v3/contactdb/lists/%d", x.listID)
+	status, body := http.StatusOK, `{}`
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == listPath:
+		body = fmt.Sprintf(`{"id": %d, "name": "list", "recipient_count": %d}`, x.listID, len(x.members))
+	case r.Method == http.MethodGet && r.URL.Path == listPath+"/recipients":
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+
+		start, end := min((page-1)*pageSize, len(x.members)), min(page*pageSize, len(x.members))
+
+		var recipients []string
+
+		for _, id := range x.members[start:end] {
+			recipients = append(recipients, fmt.Sprintf(`{"id": %q}`, id))
+		}
+
+		body = `{"recipients": [` + strings.Join(recipients, ",") + `]}`
+	case r.Method == http.MethodDelete && r.URL.Path == listPath+"/recipients":
+		var ids []string
+
+		json.NewDecoder(r.Body).Decode(&ids)
+		x.removed = append(x.removed, len(ids))
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/lists"):
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v3/contactdb/recipients/"), "/lists")
+		body = `{"lists": []}`
+
+		if slices.Contains(x.members, id) {
+			body = fmt.Sprintf(`{"lists": [{"id": %d}]}`, x.listID)
+		}
+	default:
+		status = http.StatusNotFound
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+func TestListsClient_DeleteRecipients_membershipCost(t *testing.T) {
+	transport := &listTransport{listID: 1}
+
+	for i := 0; i < 4500; i++ {
+		transport.members = append(transport.members, fmt.Sprintf("member%d", i))
+	}
+
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: transport}
+
+	// 2 recipients from a list of 5 pages are looked up individually: get, 2 lookups, delete
+	notMembers, err := c.Lists().DeleteRecipients(1, "member4499", "stranger")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if !slices.Equal(notMembers, []string{"stranger"}) || !slices.Equal(transport.removed, []int{1}) || transport.requests != 4 {
+		t.Error(notMembers, transport.removed, transport.requests)
+	}
+
+	// 6 recipients from a list of 5 pages are checked by paging: get, 5 pages, delete
+	transport.requests, transport.removed = 0, nil
+
+	notMembers, err = c.Lists().DeleteRecipients(1, "member0", "member1", "member2", "member3", "member1000", "stranger")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if !slices.Equal(notMembers, []string{"stranger"}) || !slices.Equal(transport.removed, []int{5}) || transport.requests != 7 {
+		t.Error(notMembers, transport.removed, transport.requests)
+	}
+}
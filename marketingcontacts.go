@@ -0,0 +1,203 @@
+package contacts
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Contact is a contact in the new Marketing Campaigns API, the successor to Recipient.
+type Contact struct {
+	ID                  string   `json:"id,omitempty"`
+	Email               string   `json:"email"`
+	AlternateEmails     []string `json:"alternate_emails,omitempty"`
+	FirstName           string   `json:"first_name,omitempty"`
+	LastName            string   `json:"last_name,omitempty"`
+	AddressLine1        string   `json:"address_line_1,omitempty"`
+	AddressLine2        string   `json:"address_line_2,omitempty"`
+	City                string   `json:"city,omitempty"`
+	StateProvinceRegion string   `json:"state_province_region,omitempty"`
+	PostalCode          string   `json:"postal_code,omitempty"`
+	Country             string   `json:"country,omitempty"`
+	PhoneNumber         string   `json:"phone_number,omitempty"`
+
+	// CustomFields are keyed by field definition ID, e.g. "e1_T", when writing with Upsert. Contacts read
+	// from SendGrid, by Get, GetByEmails, Search, Sample or an export, have their CustomFields keyed by
+	// field name instead.
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+
+	// ListIDs, CreatedAt and UpdatedAt are read only.
+	ListIDs   []string   `json:"list_ids,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// MarketingContactsClient provides methods for managing Contacts in the new Marketing Campaigns API.
+type MarketingContactsClient struct {
+	client *Client
+}
+
+type upsertContactsRequest struct {
+	ListIDs  []string   `json:"list_ids,omitempty"`
+	Contacts []*Contact `json:"contacts"`
+}
+
+type jobResponse struct {
+	JobID string `json:"job_id"`
+}
+
+// Upsert adds or updates Contacts, matched by email address, and adds them to the given Lists. The upsert
+// happens asynchronously; use the returned Job to wait for it.
+//
+// https://docs.sendgrid.com/api-reference/contacts/add-or-update-a-contact
+func (c *MarketingContactsClient) Upsert(ctx context.Context, listIDs []string, contacts ...*Contact) (*Job, error) {
+	if len(contacts) == 0 {
+		return nil, errors.New("contacts: no contacts to upsert")
+	}
+
+	upserts := make([]*Contact, len(contacts))
+
+	for i, contact := range contacts {
+		upsert := *contact
+		upsert.ID, upsert.ListIDs, upsert.CreatedAt, upsert.UpdatedAt = "", nil, nil, nil
+		upserts[i] = &upsert
+	}
+
+	var resp *jobResponse
+
+	err := c.client.makeRequest(ctx, "MarketingContactsClient.Upsert", http.MethodPut, "/marketing/contacts", upsertContactsRequest{ListIDs: listIDs, Contacts: upserts}, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newJob(c.client, resp.JobID), nil
+}
+
+// Delete Contacts by ID. The deletion happens asynchronously; the ID of the job is returned.
+//
+// https://docs.sendgrid.com/api-reference/contacts/delete-contacts
+func (c *MarketingContactsClient) Delete(ctx context.Context, contactIDs ...string) (string, error) {
+	if len(contactIDs) == 0 {
+		return "", errors.New("contacts: no contacts to delete")
+	}
+
+	return c.delete(ctx, "MarketingContactsClient.Delete", url.Values{"ids": {strings.Join(contactIDs, ",")}})
+}
+
+// DeleteAll deletes every Contact in the account. The deletion happens asynchronously; the ID of the job
+// is returned.
+//
+// https://docs.sendgrid.com/api-reference/contacts/delete-contacts
+func (c *MarketingContactsClient) DeleteAll(ctx context.Context) (string, error) {
+	return c.delete(ctx, "MarketingContactsClient.DeleteAll", url.Values{"delete_all_contacts": {"true"}})
+}
+
+func (c *MarketingContactsClient) delete(ctx context.Context, operation string, query url.Values) (string, error) {
+	var resp *jobResponse
+
+	err := c.client.makeRequest(ctx, operation, http.MethodDelete, "/marketing/contacts?"+query.Encode(), nil, &resp)
+
+	if err != nil {
+		return "", err
+	}
+
+	return resp.JobID, nil
+}
+
+// Get (Retrieve) a Contact by ID
+//
+// https://docs.sendgrid.com/api-reference/contacts/get-a-contact-by-id
+func (c *MarketingContactsClient) Get(ctx context.Context, contactID string) (*Contact, error) {
+	var contact *Contact
+
+	err := c.client.makeRequest(ctx, "MarketingContactsClient.Get", http.MethodGet, "/marketing/contacts/"+url.PathEscape(contactID), nil, &contact)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return contact, nil
+}
+
+type getByEmailsRequest struct {
+	Emails []string `json:"emails"`
+}
+
+type getByEmailsResponse struct {
+	Result map[string]struct {
+		Contact *Contact `json:"contact"`
+		Error   string   `json:"error"`
+	} `json:"result"`
+}
+
+// GetByEmails gets the Contacts with the given email addresses, keyed by email address. Addresses with no
+// Contact are left out.
+//
+// https://docs.sendgrid.com/api-reference/contacts/get-contacts-by-emails
+func (c *MarketingContactsClient) GetByEmails(ctx context.Context, emails ...string) (map[string]*Contact, error) {
+	var resp *getByEmailsResponse
+
+	err := c.client.makeRequest(ctx, "MarketingContactsClient.GetByEmails", http.MethodPost, "/marketing/contacts/search/emails", getByEmailsRequest{Emails: emails}, &resp)
+
+	if IsNotFound(err) {
+		// none of the addresses have a Contact
+		return map[string]*Contact{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	contacts := make(map[string]*Contact, len(resp.Result))
+
+	for email, result := range resp.Result {
+		if result.Contact != nil {
+			contacts[email] = result.Contact
+		}
+	}
+
+	return contacts, nil
+}
+
+// ContactCount is the number of Contacts in an account.
+type ContactCount struct {
+	ContactCount  int `json:"contact_count"`
+	BillableCount int `json:"billable_count"`
+}
+
+// Count gets the number of Contacts
+//
+// https://docs.sendgrid.com/api-reference/contacts/get-total-contact-count
+func (c *MarketingContactsClient) Count(ctx context.Context) (*ContactCount, error) {
+	var count *ContactCount
+
+	err := c.client.makeRequest(ctx, "MarketingContactsClient.Count", http.MethodGet, "/marketing/contacts/count", nil, &count)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return count, nil
+}
+
+type contactsResultResponse struct {
+	Result       []*Contact `json:"result"`
+	ContactCount int        `json:"contact_count"`
+}
+
+// Sample gets a sample of the most recently updated Contacts, along with the total number of Contacts.
+//
+// https://docs.sendgrid.com/api-reference/contacts/get-sample-contacts
+func (c *MarketingContactsClient) Sample(ctx context.Context) ([]*Contact, int, error) {
+	var resp *contactsResultResponse
+
+	err := c.client.makeRequest(ctx, "MarketingContactsClient.Sample", http.MethodGet, "/marketing/contacts", nil, &resp)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return resp.Result, resp.ContactCount, nil
+}
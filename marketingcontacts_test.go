@@ -0,0 +1,89 @@
+package contacts
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestMarketingContactsClient_Upsert(t *testing.T) {
+	job, err := client.MarketingContacts().Upsert(context.Background(), nil, &Contact{
+		Email:     "marketing.upsert@example.com",
+		FirstName: "Marketing",
+		LastName:  "Upsert",
+	})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	result, err := job.Wait(context.Background())
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if result.Results.CreatedCount+result.Results.UpdatedCount != 1 {
+		t.Fail()
+	}
+}
+
+func TestMarketingContactsClient_GetByEmails(t *testing.T) {
+	contacts, err := client.MarketingContacts().GetByEmails(context.Background(), "nobody.at.all@example.com")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(contacts) != 0 {
+		t.Fail()
+	}
+}
+
+func TestMarketingContactsClient_Count(t *testing.T) {
+	count, err := client.MarketingContacts().Count(context.Background())
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if count.ContactCount < 0 {
+		t.Fail()
+	}
+}
+
+func TestMarketingContactsClient_Sample(t *testing.T) {
+	contacts, total, err := client.MarketingContacts().Sample(context.Background())
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(contacts) > total {
+		t.Fail()
+	}
+}
+
+func TestMarketingContactsClient_emptyInput(t *testing.T) {
+	transport := &headerRecordingTransport{}
+
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: transport}
+
+	if _, err := c.MarketingContacts().Upsert(context.Background(), []string{"list"}); err == nil {
+		t.Fail()
+	}
+
+	if _, err := c.MarketingContacts().Delete(context.Background()); err == nil {
+		t.Fail()
+	}
+
+	if transport.url != nil {
+		t.Error(transport.url)
+	}
+}
@@ -0,0 +1,211 @@
+package contacts
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const maxListsPageSize = 1000
+
+// MarketingList is a list of Contacts in the new Marketing Campaigns API, the successor to List.
+type MarketingList struct {
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name"`
+	ContactCount int    `json:"contact_count,omitempty"`
+
+	// ContactSample is up to 50 of the List's Contacts, if requested from Get.
+	ContactSample []*Contact `json:"contact_sample,omitempty"`
+}
+
+// MarketingListsClient provides methods for managing MarketingLists in the new Marketing Campaigns API.
+type MarketingListsClient struct {
+	client *Client
+}
+
+type marketingListRequest struct {
+	Name string `json:"name"`
+}
+
+// Create a List
+//
+// https://docs.sendgrid.com/api-reference/lists/create-list
+func (c *MarketingListsClient) Create(ctx context.Context, name string) (*MarketingList, error) {
+	var list *MarketingList
+
+	err := c.client.makeRequest(ctx, "MarketingListsClient.Create", http.MethodPost, "/marketing/lists", marketingListRequest{Name: name}, &list)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Get (Retrieve) a List, optionally with a sample of its Contacts.
+//
+// https://docs.sendgrid.com/api-reference/lists/get-a-list-by-id
+func (c *MarketingListsClient) Get(ctx context.Context, listID string, contactSample bool) (*MarketingList, error) {
+	var list *MarketingList
+
+	err := c.client.makeRequest(ctx, "MarketingListsClient.Get", http.MethodGet, "/marketing/lists/"+url.PathEscape(listID)+"?contact_sample="+strconv.FormatBool(contactSample), nil, &list)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Update (rename) a List
+//
+// https://docs.sendgrid.com/api-reference/lists/update-list
+func (c *MarketingListsClient) Update(ctx context.Context, listID, name string) (*MarketingList, error) {
+	var list *MarketingList
+
+	err := c.client.makeRequest(ctx, "MarketingListsClient.Update", http.MethodPatch, "/marketing/lists/"+url.PathEscape(listID), marketingListRequest{Name: name}, &list)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Delete a List. If deleteContacts is true its Contacts are deleted too, asynchronously, and the ID of the
+// job is returned.
+//
+// https://docs.sendgrid.com/api-reference/lists/delete-a-list
+func (c *MarketingListsClient) Delete(ctx context.Context, listID string, deleteContacts bool) (string, error) {
+	var resp *jobResponse
+
+	err := c.client.makeRequest(ctx, "MarketingListsClient.Delete", http.MethodDelete, "/marketing/lists/"+url.PathEscape(listID)+"?delete_contacts="+strconv.FormatBool(deleteContacts), nil, &resp)
+
+	if err != nil || resp == nil {
+		return "", err
+	}
+
+	return resp.JobID, nil
+}
+
+// ContactCount gets the number of Contacts on a List
+//
+// https://docs.sendgrid.com/api-reference/lists/get-list-contact-count
+func (c *MarketingListsClient) ContactCount(ctx context.Context, listID string) (*ContactCount, error) {
+	var count *ContactCount
+
+	err := c.client.makeRequest(ctx, "MarketingListsClient.ContactCount", http.MethodGet, "/marketing/lists/"+url.PathEscape(listID)+"/contacts/count", nil, &count)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return count, nil
+}
+
+// RemoveContacts removes Contacts from a List without deleting them. The removal happens asynchronously;
+// the ID of the job is returned.
+//
+// https://docs.sendgrid.com/api-reference/lists/remove-contacts-from-a-list
+func (c *MarketingListsClient) RemoveContacts(ctx context.Context, listID string, contactIDs ...string) (string, error) {
+	if len(contactIDs) == 0 {
+		return "", errors.New("contacts: no contacts to remove")
+	}
+
+	var resp *jobResponse
+
+	query := url.Values{"contact_ids": {strings.Join(contactIDs, ",")}}
+
+	err := c.client.makeRequest(ctx, "MarketingListsClient.RemoveContacts", http.MethodDelete, "/marketing/lists/"+url.PathEscape(listID)+"/contacts?"+query.Encode(), nil, &resp)
+
+	if err != nil {
+		return "", err
+	}
+
+	return resp.JobID, nil
+}
+
+// pageMetadata is the cursor of a token paginated response in the new API.
+type pageMetadata struct {
+	Next string `json:"next"`
+}
+
+// nextPageToken gets the page_token from the URL of the next page, or "" if there are no more pages.
+func (m pageMetadata) nextPageToken() (string, error) {
+	if m.Next == "" {
+		return "", nil
+	}
+
+	u, err := url.Parse(m.Next)
+
+	if err != nil {
+		return "", err
+	}
+
+	return u.Query().Get("page_token"), nil
+}
+
+type marketingListsResponse struct {
+	Result   []*MarketingList `json:"result"`
+	Metadata pageMetadata     `json:"_metadata"`
+}
+
+// Page gets a page of Lists, of up to pageSize, starting from pageToken, which is empty for the first page.
+// The token of the next page is returned, which is empty on the last page.
+//
+// https://docs.sendgrid.com/api-reference/lists/get-all-lists
+func (c *MarketingListsClient) Page(ctx context.Context, pageSize int, pageToken string) ([]*MarketingList, string, error) {
+	query := url.Values{"page_size": {strconv.Itoa(pageSize)}}
+
+	if pageToken != "" {
+		query.Set("page_token", pageToken)
+	}
+
+	var resp *marketingListsResponse
+
+	err := c.client.makeRequest(ctx, "MarketingListsClient.Page", http.MethodGet, "/marketing/lists?"+query.Encode(), nil, &resp)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	next, err := resp.Metadata.nextPageToken()
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return resp.Result, next, nil
+}
+
+// All iterates over every List, following the cursor a page at a time. Iteration stops at the first error.
+func (c *MarketingListsClient) All(ctx context.Context) iter.Seq2[*MarketingList, error] {
+	return func(yield func(*MarketingList, error) bool) {
+		token := ""
+
+		for {
+			lists, next, err := c.Page(ctx, maxListsPageSize, token)
+
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, list := range lists {
+				if !yield(list, nil) {
+					return
+				}
+			}
+
+			if next == "" || next == token {
+				return
+			}
+
+			token = next
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package contacts
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type pagingTransport struct {
+	pages map[string]string
+}
+
+func (x *pagingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(x.pages[r.URL.Query().Get("page_token")])),
+		Request:    r,
+	}, nil
+}
+
+func TestMarketingListsClient_All(t *testing.T) {
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: &pagingTransport{pages: map[string]string{
+		"":    `{"result": [{"id": "a"}, {"id": "b"}], "_metadata": {"next": "https://api.sendgrid.com/v3/marketing/lists?page_size=1000&page_token=abc"}}`,
+		"abc": `{"result": [{"id": "c"}], "_metadata": {}}`,
+	}}}
+
+	var ids []string
+
+	for list, err := range c.MarketingLists().All(context.Background()) {
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		ids = append(ids, list.ID)
+	}
+
+	if strings.Join(ids, ",") != "a,b,c" {
+		t.Error(ids)
+	}
+}
+
+func TestMarketingListsClient(t *testing.T) {
+	ctx := context.Background()
+
+	list, err := client.MarketingLists().Create(ctx, "Marketing List")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	list, err = client.MarketingLists().Update(ctx, list.ID, "Renamed Marketing List")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	got, err := client.MarketingLists().Get(ctx, list.ID, true)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if got.Name != "Renamed Marketing List" {
+		t.Fail()
+	}
+
+	_, err = client.MarketingLists().Delete(ctx, list.ID, false)
+
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,479 @@
+package contacts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMigrationSampleSize = 100
+	maxGetByEmails             = 100
+)
+
+// MigrationOptions configure Client.Migrate.
+type MigrationOptions struct {
+	// SampleSize is the number of Recipients whose field values are compared with the migrated Contacts. It
+	// defaults to 100.
+	SampleSize int
+}
+
+// MigrationCheck is a comparison between the legacy contactdb and the new API made by Client.Migrate.
+type MigrationCheck struct {
+	Name     string
+	Legacy   string
+	Migrated string
+	OK       bool
+}
+
+// MigrationReport describes a migration and how the migrated data compares with the legacy data.
+type MigrationReport struct {
+	// FieldIDs, ListIDs and SegmentIDs map legacy custom field names, List IDs and Segment IDs to their
+	// new API IDs.
+	FieldIDs   map[string]string
+	ListIDs    map[uint]string
+	SegmentIDs map[uint]string
+
+	// SkippedSegments are the legacy Segments which couldn't be migrated, with the reason.
+	SkippedSegments map[uint]string
+
+	Imports []*JobResult
+	Checks  []*MigrationCheck
+}
+
+// OK reports whether every check passed.
+func (r *MigrationReport) OK() bool {
+	for _, check := range r.Checks {
+		if !check.OK {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *MigrationReport) check(name string, legacy, migrated interface{}, ok bool) {
+	r.Checks = append(r.Checks, &MigrationCheck{Name: name, Legacy: fmt.Sprint(legacy), Migrated: fmt.Sprint(migrated), OK: ok})
+}
+
+// Migrate copies the legacy contactdb to the new Marketing Campaigns API: custom fields become field
+// definitions, Lists become MarketingLists, Segments become SGQL SegmentV2s where their conditions are
+// expressible, and Recipients are imported as Contacts on the same Lists. Fields, Lists and Segments which
+// already exist in the new API by name are reused, so a failed migration can be run again. Once the imports
+// finish, the counts and a sample of field values are compared between the two APIs. Counts pass if the new
+// API has exactly as many Contacts, or at least as many if it already had some before the migration; they
+// can also lag behind imports, so failed count checks may pass if verified again later.
+func (c *Client) Migrate(ctx context.Context, opts *MigrationOptions) (*MigrationReport, error) {
+	if opts == nil {
+		opts = &MigrationOptions{}
+	}
+
+	c = c.WithContext(ctx)
+
+	report := &MigrationReport{
+		FieldIDs:        make(map[string]string),
+		ListIDs:         make(map[uint]string),
+		SegmentIDs:      make(map[uint]string),
+		SkippedSegments: make(map[uint]string),
+	}
+
+	fieldTypes, err := c.migrateFields(ctx, report)
+
+	if err != nil {
+		return report, err
+	}
+
+	lists, err := c.migrateLists(ctx, report)
+
+	if err != nil {
+		return report, err
+	}
+
+	before, err := c.MarketingContacts().Count(ctx)
+
+	if err != nil {
+		return report, err
+	}
+
+	if err := c.migrateRecipients(ctx, lists, fieldTypes, report); err != nil {
+		return report, err
+	}
+
+	if err := c.migrateSegments(ctx, fieldTypes, report); err != nil {
+		return report, err
+	}
+
+	sampleSize := opts.SampleSize
+
+	if sampleSize <= 0 {
+		sampleSize = defaultMigrationSampleSize
+	}
+
+	return report, c.verifyMigration(ctx, lists, fieldTypes, sampleSize, before.ContactCount == 0, report)
+}
+
+func (c *Client) migrateFields(ctx context.Context, report *MigrationReport) (map[string]FieldType, error) {
+	legacy, err := c.CustomFields().List()
+
+	if err != nil {
+		return nil, err
+	}
+
+	custom, _, err := c.FieldDefinitions().List(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]*FieldDefinition, len(custom))
+
+	for _, field := range custom {
+		existing[field.Name] = field
+	}
+
+	fieldTypes := make(map[string]FieldType, len(legacy))
+
+	for _, field := range legacy {
+		fieldTypes[field.Name] = field.Type
+
+		definition, ok := existing[field.Name]
+
+		if ok && definition.FieldType != field.Type {
+			return nil, fmt.Errorf("contacts: field definition %q is %s, legacy custom field is %s", field.Name, definition.FieldType, field.Type)
+		} else if !ok {
+			definition, err = c.FieldDefinitions().Create(ctx, field.Name, field.Type)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		report.FieldIDs[field.Name] = definition.ID
+	}
+
+	return fieldTypes, nil
+}
+
+func (c *Client) migrateLists(ctx context.Context, report *MigrationReport) ([]*List, error) {
+	legacy, err := c.Lists().List()
+
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]string)
+
+	for list, err := range c.MarketingLists().All(ctx) {
+		if err != nil {
+			return nil, err
+		}
+
+		existing[list.Name] = list.ID
+	}
+
+	for _, list := range legacy {
+		id, ok := existing[list.Name]
+
+		if !ok {
+			created, err := c.MarketingLists().Create(ctx, list.Name)
+
+			if err != nil {
+				return nil, err
+			}
+
+			id = created.ID
+		}
+
+		report.ListIDs[list.ID] = id
+	}
+
+	return legacy, nil
+}
+
+// migrateRecipients imports each Recipient once, onto the new Lists of every legacy List it is on. The
+// members of each List are paged first to learn which Lists each Recipient is on, holding only their IDs in
+// memory. As an import adds all of its Contacts to the same Lists, Recipients are then streamed from the
+// legacy API into one import per distinct combination of Lists, including one for those on no List.
+func (c *Client) migrateRecipients(ctx context.Context, lists []*List, fieldTypes map[string]FieldType, report *MigrationReport) error {
+	fieldNames := make([]string, 0, len(fieldTypes))
+
+	for name := range fieldTypes {
+		fieldNames = append(fieldNames, name)
+	}
+
+	sort.Strings(fieldNames)
+
+	recipientLists := make(map[string][]string)
+
+	for _, list := range lists {
+		for recipient, err := range c.Lists().AllRecipients(list.ID) {
+			if err != nil {
+				return err
+			}
+
+			recipientLists[recipient.ID] = append(recipientLists[recipient.ID], report.ListIDs[list.ID])
+		}
+	}
+
+	// legacy Lists with the same name share a new List, so the new IDs are deduplicated
+	groups := map[string][]string{"": nil}
+	groupKeys := make(map[string]string, len(recipientLists))
+
+	for id, listIDs := range recipientLists {
+		sort.Strings(listIDs)
+		listIDs = slices.Compact(listIDs)
+
+		key := strings.Join(listIDs, ",")
+		groups[key] = listIDs
+		groupKeys[id] = key
+	}
+
+	keys := make([]string, 0, len(groups))
+
+	for key := range groups {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var jobs []*Job
+
+	for _, key := range keys {
+		recipients := func(yield func(*Recipient, error) bool) {
+			for recipient, err := range c.Recipients().All() {
+				if err == nil && groupKeys[recipient.ID] != key {
+					continue
+				}
+
+				if !yield(recipient, err) {
+					return
+				}
+			}
+		}
+
+		job, err := c.MarketingContacts().importRecipients(ctx, fieldNames, recipients, groups[key]...)
+
+		if err != nil {
+			return err
+		}
+
+		if job != nil {
+			jobs = append(jobs, job)
+		}
+	}
+
+	for _, job := range jobs {
+		result, err := job.Wait(ctx)
+
+		if result != nil {
+			report.Imports = append(report.Imports, result)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) migrateSegments(ctx context.Context, fieldTypes map[string]FieldType, report *MigrationReport) error {
+	legacy, err := c.Segments().List()
+
+	if err != nil {
+		return err
+	}
+
+	existing, err := c.SegmentsV2().List(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	existingIDs := make(map[string]string, len(existing))
+
+	for _, segment := range existing {
+		existingIDs[segment.Name] = segment.ID
+	}
+
+	for _, segment := range legacy {
+		if id, ok := existingIDs[segment.Name]; ok {
+			report.SegmentIDs[segment.ID] = id
+			continue
+		}
+
+		parentListID := ""
+
+		if segment.ListID != 0 {
+			id, ok := report.ListIDs[uint(segment.ListID)]
+
+			if !ok {
+				report.SkippedSegments[segment.ID] = fmt.Sprintf("list %d was not migrated", segment.ListID)
+				continue
+			}
+
+			parentListID = id
+		}
+
+		where, err := segment.SGQL(fieldTypes, report.ListIDs)
+
+		if errors.Is(err, ErrNotExpressible) {
+			report.SkippedSegments[segment.ID] = err.Error()
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		created, err := c.SegmentsV2().Create(ctx, segment.Name, parentListID, where)
+
+		if err != nil {
+			return err
+		}
+
+		report.SegmentIDs[segment.ID] = created.ID
+	}
+
+	return nil
+}
+
+// verifyMigration compares the two APIs. If the new API had no Contacts before the migration, exact is set
+// and counts must match exactly rather than the new API having at least as many.
+func (c *Client) verifyMigration(ctx context.Context, lists []*List, fieldTypes map[string]FieldType, sampleSize int, exact bool, report *MigrationReport) error {
+	legacyCount, err := c.Recipients().Count()
+
+	if err != nil {
+		return err
+	}
+
+	count, err := c.MarketingContacts().Count(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	report.check("contact count", legacyCount, count.ContactCount, sameCount(legacyCount, count.ContactCount, exact))
+
+	for _, list := range lists {
+		count, err := c.MarketingLists().ContactCount(ctx, report.ListIDs[list.ID])
+
+		if err != nil {
+			return err
+		}
+
+		report.check("list "+strconv.Quote(list.Name)+" count", list.RecipientCount, count.ContactCount, sameCount(list.RecipientCount, count.ContactCount, exact))
+	}
+
+	var sample []*Recipient
+
+	for recipient, err := range c.Recipients().All() {
+		if err != nil {
+			return err
+		}
+
+		sample = append(sample, recipient)
+
+		if len(sample) == sampleSize {
+			break
+		}
+	}
+
+	for start := 0; start < len(sample); start += maxGetByEmails {
+		batch := sample[start:min(start+maxGetByEmails, len(sample))]
+		emails := make([]string, len(batch))
+
+		for i, recipient := range batch {
+			emails[i] = recipient.Email
+		}
+
+		migrated, err := c.MarketingContacts().GetByEmails(ctx, emails...)
+
+		if err != nil {
+			return err
+		}
+
+		for _, recipient := range batch {
+			verifyContact(recipient, findContact(migrated, recipient.Email), fieldTypes, report)
+		}
+	}
+
+	return nil
+}
+
+// sameCount reports whether a migrated count matches a legacy one: exactly if exact is set, otherwise if the
+// new API has at least as many, as it may already have had some.
+func sameCount(legacy, migrated int, exact bool) bool {
+	if exact {
+		return migrated == legacy
+	}
+
+	return migrated >= legacy
+}
+
+// findContact finds a Contact by email address, which the new API may have normalised to lower case.
+func findContact(contacts map[string]*Contact, email string) *Contact {
+	for key, contact := range contacts {
+		if strings.EqualFold(key, email) {
+			return contact
+		}
+	}
+
+	return nil
+}
+
+func verifyContact(recipient *Recipient, contact *Contact, fieldTypes map[string]FieldType, report *MigrationReport) {
+	prefix := recipient.Email + " "
+
+	if contact == nil {
+		report.check(prefix+"exists", true, false, false)
+		return
+	}
+
+	report.check(prefix+"first_name", recipient.FirstName, contact.FirstName, recipient.FirstName == contact.FirstName)
+	report.check(prefix+"last_name", recipient.LastName, contact.LastName, recipient.LastName == contact.LastName)
+
+	for _, field := range recipient.CustomFields {
+		if field.Value == nil {
+			continue
+		}
+
+		// Contacts are read with custom fields keyed by name, but fall back to the ID they are written by
+		migrated, ok := contact.CustomFields[field.Name]
+
+		if !ok {
+			migrated = contact.CustomFields[report.FieldIDs[field.Name]]
+		}
+
+		report.check(prefix+field.Name, field.Value, migrated, sameFieldValue(fieldTypes[field.Name], field.Value, migrated))
+	}
+}
+
+// sameFieldValue compares a legacy custom field value with a migrated one. Numbers are compared
+// numerically and dates by day, as the two APIs represent them differently.
+func sameFieldValue(fieldType FieldType, legacy, migrated interface{}) bool {
+	if migrated == nil {
+		return false
+	}
+
+	switch fieldType {
+	case FieldTypeNumber, FieldTypeDate:
+		l, lok := numericValue(legacy)
+		m, mok := numericValue(migrated)
+
+		if !lok || !mok {
+			return false
+		}
+
+		if fieldType == FieldTypeDate {
+			return time.Unix(int64(l), 0).UTC().Format("2006-01-02") == time.Unix(int64(m), 0).UTC().Format("2006-01-02")
+		}
+
+		return l == m
+	default:
+		return fmt.Sprint(legacy) == fmt.Sprint(migrated)
+	}
+}
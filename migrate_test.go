@@ -0,0 +1,156 @@
+package contacts
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"maps"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestVerifyContact(t *testing.T) {
+	recipient := &Recipient{
+		Email:     "john@example.com",
+		FirstName: "John",
+		CustomFields: []CustomField{
+			{Name: "age", Value: float64(30)},
+			{Name: "birthday", Value: float64(1577923200)},
+			{Name: "pet", Value: "dog"},
+		},
+	}
+
+	fieldTypes := map[string]FieldType{"age": FieldTypeNumber, "birthday": FieldTypeDate, "pet": FieldTypeText}
+
+	report := &MigrationReport{FieldIDs: map[string]string{"age": "e1_N", "birthday": "e2_D", "pet": "e3_T"}}
+
+	verifyContact(recipient, &Contact{
+		Email:        "john@example.com",
+		FirstName:    "John",
+		CustomFields: map[string]interface{}{"age": float64(30), "birthday": "2020-01-02T00:00:00Z", "e3_T": "dog"},
+	}, fieldTypes, report)
+
+	if !report.OK() || len(report.Checks) != 5 {
+		t.Error(report.Checks)
+	}
+
+	verifyContact(recipient, &Contact{
+		Email:        "john@example.com",
+		FirstName:    "Jon",
+		CustomFields: map[string]interface{}{"age": float64(31)},
+	}, fieldTypes, report)
+
+	failed := 0
+
+	for _, check := range report.Checks {
+		if !check.OK {
+			failed++
+		}
+	}
+
+	if failed != 4 {
+		t.Error(report.Checks)
+	}
+}
+
+// migrateTransport fakes the legacy Lists and Recipients, and records the Contacts imported by each job.
+type migrateTransport struct {
+	lists      map[uint][]string
+	recipients []string
+	imports    [][]string
+	imported   map[string]string
+}
+
+func (x *migrateTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	body := `{}`
+
+	recipientsJSON := func(emails []string) string {
+		var recipients []string
+
+		for _, email := range emails {
+			recipients = append(recipients, fmt.Sprintf(`{"id": %q, "email": %q}`, ToRecipientID(email), email))
+		}
+
+		return `{"recipients": [` + strings.Join(recipients, ",") + `]}`
+	}
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/v3/contactdb/lists/"):
+		var id uint
+
+		fmt.Sscanf(r.URL.Path, "/v3/contactdb/lists/%d/recipients", &id)
+		body = recipientsJSON(x.lists[id])
+	case r.URL.Path == "/v3/contactdb/recipients":
+		body = recipientsJSON(x.recipients)
+	case r.URL.Path == "/v3/marketing/field_definitions":
+		body = `{"reserved_fields": [{"id": "_rf0_T", "name": "first_name"}, {"id": "_rf1_T", "name": "email"}, {"id": "_rf2_T", "name": "last_name"}]}`
+	case r.URL.Path == "/v3/marketing/contacts/imports":
+		var req contactImportRequest
+
+		json.NewDecoder(r.Body).Decode(&req)
+		x.imports = append(x.imports, req.ListIDs)
+
+		body = fmt.Sprintf(`{"job_id": "job-%d", "upload_uri": "https://upload.example.com/%d"}`, len(x.imports), len(x.imports)-1)
+	case r.URL.Host == "upload.example.com":
+		zr, err := gzip.NewReader(r.Body)
+
+		if err != nil {
+			return nil, err
+		}
+
+		records, _ := csv.NewReader(zr).ReadAll()
+
+		for _, record := range records[1:] {
+			if _, ok := x.imported[record[0]]; ok {
+				return nil, fmt.Errorf("%s imported twice", record[0])
+			}
+
+			x.imported[record[0]] = strings.Join(x.imports[len(x.imports)-1], ",")
+		}
+	case strings.HasPrefix(r.URL.Path, "/v3/marketing/contacts/imports/"):
+		body = fmt.Sprintf(`{"id": %q, "status": "completed"}`, strings.TrimPrefix(r.URL.Path, "/v3/marketing/contacts/imports/"))
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+func TestClient_migrateRecipients(t *testing.T) {
+	transport := &migrateTransport{
+		lists:      map[uint][]string{1: {"a@example.com", "b@example.com"}, 2: {"b@example.com"}, 3: {"b@example.com"}},
+		recipients: []string{"a@example.com", "b@example.com", "c@example.com"},
+		imported:   make(map[string]string),
+	}
+
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: transport}
+
+	// lists 2 and 3 have the same name, so share a new List
+	report := &MigrationReport{ListIDs: map[uint]string{1: "m1", 2: "m2", 3: "m2"}}
+
+	err := c.migrateRecipients(context.Background(), []*List{{ID: 1}, {ID: 2}, {ID: 3}}, nil, report)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	want := map[string]string{"a@example.com": "m1", "b@example.com": "m1,m2", "c@example.com": ""}
+
+	if !maps.Equal(transport.imported, want) || len(transport.imports) != 3 || len(report.Imports) != 3 {
+		t.Error(transport.imported, transport.imports)
+	}
+}
+
+func TestSameCount(t *testing.T) {
+	if !sameCount(2, 3, false) || sameCount(2, 3, true) || !sameCount(2, 2, true) || sameCount(2, 1, false) {
+		t.Fail()
+	}
+}
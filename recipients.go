@@ -3,9 +3,12 @@ package contacts
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 // ToRecipientID converts an email address to a SendGrid recipient ID
@@ -24,7 +27,7 @@ type Recipient struct {
 	CreatedAt int    `json:"created_at"`
 	// @TODO there are missing fields here
 
-	CustomFields []CustomField `json:"-"` // @TODO custom unmarshal json for this
+	CustomFields []CustomField `json:"-"`
 }
 
 func (r *Recipient) MarshalJSON() ([]byte, error) {
@@ -49,6 +52,61 @@ func (r *Recipient) MarshalJSON() ([]byte, error) {
 	return json.Marshal(fields)
 }
 
+func (r *Recipient) UnmarshalJSON(b []byte) error {
+	type recipient Recipient
+
+	aux := struct {
+		*recipient
+		CustomFields []struct {
+			ID    uint        `json:"id"`
+			Name  string      `json:"name"`
+			Type  FieldType   `json:"type"`
+			Value interface{} `json:"value"`
+		} `json:"custom_fields"`
+	}{recipient: (*recipient)(r)}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	r.CustomFields = nil
+
+	for _, f := range aux.CustomFields {
+		r.CustomFields = append(r.CustomFields, CustomField{ID: f.ID, Name: f.Name, Type: f.Type, Value: f.Value})
+	}
+
+	return nil
+}
+
+// CustomField returns the value of the named custom field on the Recipient, or nil if it is not set.
+func (r *Recipient) CustomField(name string) interface{} {
+	for _, f := range r.CustomFields {
+		if f.Name == name {
+			return f.Value
+		}
+	}
+
+	return nil
+}
+
+// fieldValue returns the value of a reserved or custom field on the Recipient, or nil if it is not set.
+func (r *Recipient) fieldValue(name string) interface{} {
+	switch name {
+	case "id":
+		return r.ID
+	case "email":
+		return r.Email
+	case "first_name":
+		return r.FirstName
+	case "last_name":
+		return r.LastName
+	case "created_at":
+		return r.CreatedAt
+	default:
+		return r.CustomField(name)
+	}
+}
+
 // RecipientClient defines methods for interacting with Recipients
 type RecipientClient struct {
 	client *Client
@@ -68,6 +126,10 @@ type RecipientResponse struct {
 	}
 }
 
+func (r *RecipientResponse) errorCount() int {
+	return r.ErrorCount
+}
+
 func contains(s []int, e int) bool {
 	for _, a := range s {
 		if a == e {
@@ -99,33 +161,82 @@ func (c *RecipientClient) attachIDs(resp *RecipientResponse, recipients []*Recip
 //
 // https://sendgrid.com/docs/API_Reference/Web_API_v3/Marketing_Campaigns/contactdb.html#Add-Multiple-Recipients-POST
 func (c *RecipientClient) Add(recipients ...*Recipient) (resp *RecipientResponse, err error) {
-	err = c.client.makeRequest(http.MethodPost, "/contactdb/recipients", recipients, &resp)
+	err = c.client.makeRequest(c.client.context(), "RecipientClient.Add", http.MethodPost, "/contactdb/recipients", recipients, &resp)
 
 	c.attachIDs(resp, recipients)
 
 	return resp, err
 }
 
+// addEmails adds Recipients with only an email address, leaving the other fields of any which already exist
+// untouched, unlike Add which would clear their names.
+func (c *RecipientClient) addEmails(emails []string) (resp *RecipientResponse, err error) {
+	recipients := make([]map[string]string, len(emails))
+
+	for i, email := range emails {
+		recipients[i] = map[string]string{"email": email}
+	}
+
+	err = c.client.makeRequest(c.client.context(), "RecipientClient.Add", http.MethodPost, "/contactdb/recipients", recipients, &resp)
+
+	return resp, err
+}
+
 // Update a Recipient.
 //
 // https://sendgrid.com/docs/API_Reference/Web_API_v3/Marketing_Campaigns/contactdb.html#Update-Recipient-PATCH
 func (c *RecipientClient) Update(recipients ...*Recipient) (resp *RecipientResponse, err error) {
-	err = c.client.makeRequest(http.MethodPatch, "/contactdb/recipients", recipients, &resp)
+	err = c.client.makeRequest(c.client.context(), "RecipientClient.Update", http.MethodPatch, "/contactdb/recipients", recipients, &resp)
 
 	c.attachIDs(resp, recipients)
 
 	return resp, err
 }
 
+// updateFields adds or updates Recipients given as maps of field name to value, leaving any fields which
+// aren't given untouched, unlike Update which would clear them.
+func (c *RecipientClient) updateFields(recipients []map[string]interface{}) (resp *RecipientResponse, err error) {
+	err = c.client.makeRequest(c.client.context(), "RecipientClient.Update", http.MethodPatch, "/contactdb/recipients", recipients, &resp)
+
+	return resp, err
+}
+
 // Delete one or more Recipients
 //
 // https://sendgrid.com/docs/API_Reference/Web_API_v3/Marketing_Campaigns/contactdb.html#Delete-Recipient-DELETE
 func (c *RecipientClient) Delete(recipientIDs []string) error {
-	return c.client.makeRequest(http.MethodDelete, "/contactdb/recipients", recipientIDs, nil)
+	return c.client.makeRequest(c.client.context(), "RecipientClient.Delete", http.MethodDelete, "/contactdb/recipients", recipientIDs, nil)
 }
 
 type listRecipientsResponse struct {
 	Recipients []*Recipient `json:"recipients"`
+
+	// each, if set, is called with each Recipient as it is decoded, instead of collecting them in Recipients.
+	each func(*Recipient) error
+}
+
+func (r *listRecipientsResponse) decodeStream(dec *json.Decoder) error {
+	return decodeObject(dec, func(dec *json.Decoder, key string) error {
+		if key != "recipients" {
+			return skipValue(dec)
+		}
+
+		return decodeArray(dec, func(dec *json.Decoder) error {
+			var recipient *Recipient
+
+			if err := dec.Decode(&recipient); err != nil {
+				return err
+			}
+
+			if r.each != nil {
+				return r.each(recipient)
+			}
+
+			r.Recipients = append(r.Recipients, recipient)
+
+			return nil
+		})
+	})
 }
 
 // List Recipients
@@ -134,7 +245,7 @@ type listRecipientsResponse struct {
 func (c *RecipientClient) List(page int, pageSize int) ([]*Recipient, error) {
 	var recipients listRecipientsResponse
 
-	err := c.client.makeRequest(http.MethodGet, fmt.Sprintf("/contactdb/recipients?page=%d&page_size=%d", page, pageSize), nil, &recipients)
+	err := c.client.makeRequest(c.client.context(), "RecipientClient.List", http.MethodGet, fmt.Sprintf("/contactdb/recipients?page=%d&page_size=%d", page, pageSize), nil, &recipients)
 
 	if err != nil {
 		return nil, err
@@ -143,13 +254,65 @@ func (c *RecipientClient) List(page int, pageSize int) ([]*Recipient, error) {
 	return recipients.Recipients, nil
 }
 
+// ListFunc calls fn with each Recipient on a page as it is read from the response, so that large pages
+// are never held in memory in full. If fn returns an error, ListFunc stops and returns it.
+//
+// https://sendgrid.com/docs/API_Reference/Web_API_v3/Marketing_Campaigns/contactdb.html#List-Recipients-GET
+func (c *RecipientClient) ListFunc(page int, pageSize int, fn func(*Recipient) error) error {
+	return c.client.makeRequest(c.client.context(), "RecipientClient.List", http.MethodGet, fmt.Sprintf("/contactdb/recipients?page=%d&page_size=%d", page, pageSize), nil, &listRecipientsResponse{each: fn})
+}
+
+// maxPageSize is the largest page of Recipients SendGrid returns.
+const maxPageSize = 1000
+
+// errStopIteration is returned from a page callback to stop reading when an iterator's consumer stops.
+var errStopIteration = errors.New("contacts: stop iteration")
+
+// pageRecipients iterates over every Recipient from a paged endpoint, calling fetch for each page
+// (starting at 1) until a page has fewer than maxPageSize Recipients.
+func pageRecipients(fetch func(page uint, fn func(*Recipient) error) error) iter.Seq2[*Recipient, error] {
+	return func(yield func(*Recipient, error) bool) {
+		for page := uint(1); ; page++ {
+			count := 0
+
+			err := fetch(page, func(r *Recipient) error {
+				count++
+
+				if !yield(r, nil) {
+					return errStopIteration
+				}
+
+				return nil
+			})
+
+			if errors.Is(err, errStopIteration) {
+				return
+			} else if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if count < maxPageSize {
+				return
+			}
+		}
+	}
+}
+
+// All iterates over every Recipient, fetching them a page at a time. Iteration stops at the first error.
+func (c *RecipientClient) All() iter.Seq2[*Recipient, error] {
+	return pageRecipients(func(page uint, fn func(*Recipient) error) error {
+		return c.ListFunc(int(page), maxPageSize, fn)
+	})
+}
+
 // Get (Retrieve) a Recipient
 //
 // https://sendgrid.com/docs/API_Reference/Web_API_v3/Marketing_Campaigns/contactdb.html#Retrieve-a-Recipient-GET
 func (c *RecipientClient) Get(recipientID string) (*Recipient, error) {
 	var recipient *Recipient
 
-	err := c.client.makeRequest(http.MethodGet, "/contactdb/recipients/"+recipientID, nil, &recipient)
+	err := c.client.makeRequest(c.client.context(), "RecipientClient.Get", http.MethodGet, "/contactdb/recipients/"+recipientID, nil, &recipient)
 
 	if err != nil {
 		return nil, err
@@ -162,15 +325,76 @@ func (c *RecipientClient) Get(recipientID string) (*Recipient, error) {
 //
 // https://sendgrid.com/docs/API_Reference/Web_API_v3/Marketing_Campaigns/contactdb.html#Get-the-Lists-the-Recipient-Is-On-GET
 func (c *RecipientClient) ListsForRecipient(recipientID string) ([]List, error) {
-	var lists []List
+	var resp listListsResponse
 
-	err := c.client.makeRequest(http.MethodGet, "/contactdb/recipients/"+recipientID+"/lists", nil, &lists)
+	err := c.client.makeRequest(c.client.context(), "RecipientClient.ListsForRecipient", http.MethodGet, "/contactdb/recipients/"+recipientID+"/lists", nil, &resp)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return lists, err
+	lists := make([]List, len(resp.Lists))
+
+	for i, list := range resp.Lists {
+		lists[i] = *list
+	}
+
+	return lists, nil
+}
+
+// RecipientProfile is everything held about a Recipient.
+type RecipientProfile struct {
+	Recipient *Recipient
+	Lists     []List
+	Segments  []*Segment
+
+	// UnknownSegments are the Segments whose membership depends on engagement data, such as last_opened,
+	// which can't be evaluated locally.
+	UnknownSegments []*Segment
+}
+
+// Lookup finds the Recipient with the given email address, along with the Lists it is on and the Segments
+// whose conditions it matches. Segment membership is evaluated locally with Segment.Matches, rather than
+// by paging through every Segment; Segments which depend on engagement data are reported separately as
+// UnknownSegments. If there is no such Recipient, the error satisfies IsNotFound.
+func (c *RecipientClient) Lookup(email string) (*RecipientProfile, error) {
+	recipient, err := c.Get(ToRecipientID(strings.ToLower(strings.TrimSpace(email))))
+
+	if err != nil {
+		return nil, err
+	}
+
+	lists, err := c.ListsForRecipient(recipient.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	segments, err := c.client.Segments().List()
+
+	if err != nil {
+		return nil, err
+	}
+
+	listIDs := make([]uint, len(lists))
+
+	for i, list := range lists {
+		listIDs[i] = list.ID
+	}
+
+	profile := &RecipientProfile{Recipient: recipient, Lists: lists}
+
+	for _, segment := range segments {
+		matched, known := segment.Matches(recipient, listIDs)
+
+		if !known {
+			profile.UnknownSegments = append(profile.UnknownSegments, segment)
+		} else if matched {
+			profile.Segments = append(profile.Segments, segment)
+		}
+	}
+
+	return profile, nil
 }
 
 type recipientCountResponse struct {
@@ -183,7 +407,7 @@ type recipientCountResponse struct {
 func (c *RecipientClient) BillableCount() (int, error) {
 	var recipientCount recipientCountResponse
 
-	err := c.client.makeRequest(http.MethodGet, "/contactdb/recipients/billable_count", nil, &recipientCount)
+	err := c.client.makeRequest(c.client.context(), "RecipientClient.BillableCount", http.MethodGet, "/contactdb/recipients/billable_count", nil, &recipientCount)
 
 	if err != nil {
 		return -1, err
@@ -198,7 +422,7 @@ func (c *RecipientClient) BillableCount() (int, error) {
 func (c *RecipientClient) Count() (int, error) {
 	var recipientCount recipientCountResponse
 
-	err := c.client.makeRequest(http.MethodGet, "/contactdb/recipients/count", nil, &recipientCount)
+	err := c.client.makeRequest(c.client.context(), "RecipientClient.Count", http.MethodGet, "/contactdb/recipients/count", nil, &recipientCount)
 
 	if err != nil {
 		return -1, err
@@ -218,7 +442,7 @@ type recipientSearch struct {
 func (c *RecipientClient) SearchListWithConditions(listID int, conditions ...Condition) ([]*Recipient, error) {
 	var recipients listRecipientsResponse
 
-	err := c.client.makeRequest(http.MethodGet, "/contactdb/recipients/search", recipientSearch{ListID: listID, Conditions: conditions}, &recipients)
+	err := c.client.makeRequest(c.client.context(), "RecipientClient.SearchListWithConditions", http.MethodGet, "/contactdb/recipients/search", recipientSearch{ListID: listID, Conditions: conditions}, &recipients)
 
 	if err != nil {
 		return nil, err
@@ -245,14 +469,14 @@ func (c *RecipientClient) Search(criteria ...SearchTerm) ([]*Recipient, error) {
 	q := u.Query()
 
 	for _, term := range criteria {
-		q.Add(term.FieldName, url.QueryEscape(term.FieldValue))
+		q.Add(term.FieldName, term.FieldValue)
 	}
 
 	u.RawQuery = q.Encode()
 
 	var recipients listRecipientsResponse
 
-	err = c.client.makeRequest(http.MethodGet, u.String(), nil, &recipients)
+	err = c.client.makeRequest(c.client.context(), "RecipientClient.Search", http.MethodGet, u.String(), nil, &recipients)
 
 	if err != nil {
 		return nil, err
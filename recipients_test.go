@@ -1,6 +1,10 @@
 package contacts
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
@@ -142,7 +146,89 @@ func TestRecipientClient_Get(t *testing.T) {
 }
 
 func TestRecipientClient_ListsForRecipient(t *testing.T) {
-	// @TODO.
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: &listTransport{listID: 7, members: []string{"abc"}}}
+
+	lists, err := c.Recipients().ListsForRecipient("abc")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(lists) != 1 || lists[0].ID != 7 {
+		t.Error(lists)
+	}
+}
+
+func TestRecipientClient_Lookup(t *testing.T) {
+	r := &Recipient{FirstName: "Lookup", LastName: "Test", Email: "lookup.test@example.com"}
+
+	_, err := client.Recipients().Add(r)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	profile, err := client.Recipients().Lookup("Lookup.Test@example.com")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if profile.Recipient == nil || profile.Recipient.Email != r.Email {
+		t.Fail()
+	}
+
+	_, err = client.Recipients().Lookup("nobody.at.all@example.com")
+
+	if !IsNotFound(err) {
+		t.Error(err)
+	}
+}
+
+// lookupTransport fakes a Recipient on List 7, and Segments on its last name and on engagement data.
+type lookupTransport struct{}
+
+func (lookupTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	body := `{}`
+
+	switch r.URL.Path {
+	case "/v3/contactdb/recipients/" + ToRecipientID("john@example.com"):
+		body = `{"id": "` + ToRecipientID("john@example.com") + `", "email": "john@example.com", "last_name": "Doe"}`
+	case "/v3/contactdb/recipients/" + ToRecipientID("john@example.com") + "/lists":
+		body = `{"lists": [{"id": 7}]}`
+	case "/v3/contactdb/segments":
+		body = `{"segments": [
+			{"id": 1, "conditions": [{"field": "last_name", "value": "Doe", "operator": "eq", "and_or": ""}]},
+			{"id": 2, "conditions": [{"field": "last_opened", "value": "01/02/2015", "operator": "gt", "and_or": ""}]},
+			{"id": 3, "list_id": 8}
+		]}`
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+func TestRecipientClient_Lookup_engagement(t *testing.T) {
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: lookupTransport{}}
+
+	profile, err := c.Recipients().Lookup("john@example.com")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(profile.Segments) != 1 || profile.Segments[0].ID != 1 || len(profile.UnknownSegments) != 1 || profile.UnknownSegments[0].ID != 2 {
+		t.Error(profile.Segments, profile.UnknownSegments)
+	}
 }
 
 func TestRecipientClient_BillableCount(t *testing.T) {
@@ -184,3 +270,98 @@ func TestRecipientClient_Search(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestRecipientClient_Search_escaping(t *testing.T) {
+	transport := &headerRecordingTransport{}
+
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: transport}
+
+	_, err := c.Recipients().Search(SearchTerm{FieldName: "email", FieldValue: "john+doe@example.com"})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if transport.url.RawQuery != "email=john%2Bdoe%40example.com" || transport.url.Query().Get("email") != "john+doe@example.com" {
+		t.Error(transport.url.RawQuery)
+	}
+}
+
+func TestListRecipientsResponse_decodeStream(t *testing.T) {
+	body := `{"recipients": [{"id": "a", "email": "a@example.com"}, {"id": "b", "email": "b@example.com"}], "extra": {"ignored": [1, 2]}}`
+
+	var ids []string
+
+	resp := &listRecipientsResponse{each: func(r *Recipient) error {
+		ids = append(ids, r.ID)
+		return nil
+	}}
+
+	err := client.unmarshal(strings.NewReader(body), resp)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(ids) != 2 || ids[0] != "a" || ids[1] != "b" || len(resp.Recipients) != 0 {
+		t.Fail()
+	}
+}
+
+func TestRecipient_UnmarshalJSON(t *testing.T) {
+	var r Recipient
+
+	err := json.Unmarshal([]byte(`{"id": "a", "email": "a@example.com", "custom_fields": [{"id": 1, "name": "pet", "type": "text", "value": "dog"}]}`), &r)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if r.ID != "a" || r.Email != "a@example.com" || r.CustomField("pet") != "dog" || r.CustomFields[0].ID != 1 {
+		t.Fail()
+	}
+}
+
+func TestPageRecipients(t *testing.T) {
+	fetched := 0
+
+	recipients := pageRecipients(func(page uint, fn func(*Recipient) error) error {
+		fetched++
+
+		n := maxPageSize
+
+		if page == 2 {
+			n = 3
+		}
+
+		for i := 0; i < n; i++ {
+			if err := fn(&Recipient{}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	count := 0
+
+	for _, err := range recipients {
+		if err != nil {
+			t.Error(err)
+		}
+
+		count++
+	}
+
+	if count != maxPageSize+3 || fetched != 2 {
+		t.Fail()
+	}
+
+	for range recipients {
+		break
+	}
+}
@@ -0,0 +1,138 @@
+package contacts
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strings"
+)
+
+// ErrEmptyDesired is returned by ListsClient.Reconcile when there are no desired email addresses, which would
+// remove every member of the List. This is usually a mistake upstream, such as an empty query result.
+var ErrEmptyDesired = errors.New("contacts: no desired email addresses")
+
+// ReconcileOptions configure ListsClient.Reconcile.
+type ReconcileOptions struct {
+	// AllowEmpty lets an empty set of desired email addresses remove every member of the List, rather than
+	// returning ErrEmptyDesired.
+	AllowEmpty bool
+}
+
+// ReconcileSummary describes the changes made by ListsClient.Reconcile.
+type ReconcileSummary struct {
+	// Desired is the number of distinct email addresses which should be on the List.
+	Desired int
+	// Kept is the number of Recipients which were already on the List.
+	Kept int
+	// Added is the number of Recipients added to the List.
+	Added int
+	// Created is the number of Recipients which did not exist and were created.
+	Created int
+	// Removed is the number of Recipients removed from the List.
+	Removed int
+	// Rejected are the email addresses which SendGrid would not create Recipients for.
+	Rejected []string
+}
+
+// Reconcile makes the membership of a List exactly match desiredEmails. The List's current members are
+// paged through and compared with the desired addresses, Recipients are created for addresses which don't
+// have one, and the additions and removals are applied in batches. Only the desired addresses and the
+// difference are held in memory, never the List's full membership. If there are no desired addresses,
+// ErrEmptyDesired is returned without changing the List unless opts allows it.
+func (c *ListsClient) Reconcile(ctx context.Context, listID uint, desiredEmails iter.Seq[string], opts *ReconcileOptions) (*ReconcileSummary, error) {
+	if opts == nil {
+		opts = &ReconcileOptions{}
+	}
+
+	client := c.client.WithContext(ctx)
+	lists := client.Lists()
+
+	// desired recipient IDs to email addresses. Once the List's members are removed, the remainder are added.
+	desired := make(map[string]string)
+
+	for email := range desiredEmails {
+		email = strings.ToLower(strings.TrimSpace(email))
+
+		if email != "" {
+			desired[ToRecipientID(email)] = email
+		}
+	}
+
+	if len(desired) == 0 && !opts.AllowEmpty {
+		return nil, ErrEmptyDesired
+	}
+
+	summary := &ReconcileSummary{Desired: len(desired)}
+
+	var remove []string
+
+	for recipient, err := range lists.AllRecipients(listID) {
+		if err != nil {
+			return summary, err
+		}
+
+		if _, ok := desired[recipient.ID]; ok {
+			delete(desired, recipient.ID)
+			summary.Kept++
+		} else {
+			remove = append(remove, recipient.ID)
+		}
+	}
+
+	if err := lists.deleteRecipients(listID, remove); err != nil {
+		return summary, err
+	}
+
+	summary.Removed = len(remove)
+
+	batch := make([]string, 0, maxBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		resp, err := client.Recipients().addEmails(batch)
+
+		if err != nil {
+			return err
+		}
+
+		summary.Created += resp.NewCount
+
+		var ids []string
+
+		for i, email := range batch {
+			if contains(resp.ErrorIndices, i) {
+				summary.Rejected = append(summary.Rejected, email)
+				continue
+			}
+
+			ids = append(ids, ToRecipientID(email))
+		}
+
+		if len(ids) > 0 {
+			if err := lists.AddRecipientsByIDs(listID, ids...); err != nil {
+				return err
+			}
+
+			summary.Added += len(ids)
+		}
+
+		batch = batch[:0]
+
+		return nil
+	}
+
+	for _, email := range desired {
+		batch = append(batch, email)
+
+		if len(batch) == maxBatchSize {
+			if err := flush(); err != nil {
+				return summary, err
+			}
+		}
+	}
+
+	return summary, flush()
+}
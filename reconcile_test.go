@@ -0,0 +1,87 @@
+package contacts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestListsClient_Reconcile(t *testing.T) {
+	list, err := client.Lists().Create("reconcile_list")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	stale := &Recipient{Email: "reconcile.stale@example.com"}
+
+	_, err = client.Recipients().Add(stale)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	err = client.Lists().AddRecipients(list.ID, stale)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	// adding recipients to lists takes some time it seems...
+	time.Sleep(time.Second * 5)
+
+	desired := []string{"reconcile.one@example.com", "Reconcile.Two@example.com "}
+
+	summary, err := client.Lists().Reconcile(context.Background(), list.ID, slices.Values(desired), nil)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if summary.Desired != 2 || summary.Added != 2 || summary.Removed != 1 || summary.Kept != 0 {
+		t.Fail()
+	}
+}
+
+func TestListsClient_Reconcile_batchesRemovals(t *testing.T) {
+	transport := &listTransport{listID: 1}
+
+	for i := 0; i < 2500; i++ {
+		transport.members = append(transport.members, ToRecipientID(fmt.Sprintf("stale%d@example.com", i)))
+	}
+
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: transport}
+
+	summary, err := c.Lists().Reconcile(context.Background(), 1, slices.Values([]string{}), &ReconcileOptions{AllowEmpty: true})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if summary.Removed != 2500 || !slices.Equal(transport.removed, []int{1000, 1000, 500}) {
+		t.Error(summary, transport.removed)
+	}
+}
+
+func TestListsClient_Reconcile_emptyDesired(t *testing.T) {
+	transport := &listTransport{listID: 1, members: []string{ToRecipientID("john@example.com")}}
+
+	c := New("apikey")
+	c.HTTPClient = &http.Client{Transport: transport}
+
+	_, err := c.Lists().Reconcile(context.Background(), 1, slices.Values([]string{"", " "}), nil)
+
+	if !errors.Is(err, ErrEmptyDesired) || transport.requests != 0 {
+		t.Error(err, transport.requests)
+	}
+}
@@ -0,0 +1,213 @@
+package contacts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const (
+	scopeCreate = "marketing_campaigns.create"
+	scopeRead   = "marketing_campaigns.read"
+	scopeUpdate = "marketing_campaigns.update"
+	scopeDelete = "marketing_campaigns.delete"
+
+	scopeSuppress = "asm.suppressions.global.create"
+)
+
+// requiredScopes are the API key scopes needed by each operation, keyed by operation name. Operations list
+// every scope they may use, e.g. Client.Forget only suppresses addresses if ForgetOptions.Suppress is set.
+var requiredScopes = map[string][]string{
+	"CustomFieldsClient.Create":         {scopeCreate},
+	"CustomFieldsClient.List":           {scopeRead},
+	"CustomFieldsClient.Get":            {scopeRead},
+	"CustomFieldsClient.Delete":         {scopeDelete},
+	"CustomFieldsClient.ReservedFields": {scopeRead},
+
+	"ListsClient.Create":               {scopeCreate},
+	"ListsClient.List":                 {scopeRead},
+	"ListsClient.Delete":               {scopeDelete},
+	"ListsClient.Get":                  {scopeRead},
+	"ListsClient.Update":               {scopeUpdate},
+	"ListsClient.ListRecipients":       {scopeRead},
+	"ListsClient.AddRecipients":        {scopeCreate},
+	"ListsClient.AddRecipientsByIDs":   {scopeCreate},
+	"ListsClient.DeleteRecipient":      {scopeDelete},
+	"ListsClient.DeleteRecipientByID":  {scopeDelete},
+	"ListsClient.DeleteRecipients":     {scopeRead, scopeDelete},
+	"ListsClient.Reconcile":            {scopeCreate, scopeRead, scopeDelete},
+	"ListsClient.Union":                {scopeRead},
+	"ListsClient.Intersection":         {scopeRead},
+	"ListsClient.Difference":           {scopeRead},
+	"ListsClient.CreateWithRecipients": {scopeCreate},
+
+	"RecipientClient.Add":                      {scopeCreate},
+	"RecipientClient.Update":                   {scopeUpdate},
+	"RecipientClient.Delete":                   {scopeDelete},
+	"RecipientClient.List":                     {scopeRead},
+	"RecipientClient.Get":                      {scopeRead},
+	"RecipientClient.ListsForRecipient":        {scopeRead},
+	"RecipientClient.BillableCount":            {scopeRead},
+	"RecipientClient.Count":                    {scopeRead},
+	"RecipientClient.SearchListWithConditions": {scopeRead},
+	"RecipientClient.Search":                   {scopeRead},
+	"RecipientClient.ImportCSV":                {scopeCreate, scopeRead},
+	"RecipientClient.Lookup":                   {scopeRead},
+
+	"SegmentsClient.Create":         {scopeCreate},
+	"SegmentsClient.List":           {scopeRead},
+	"SegmentsClient.Get":            {scopeRead},
+	"SegmentsClient.Update":         {scopeUpdate},
+	"SegmentsClient.Delete":         {scopeDelete},
+	"SegmentsClient.ListRecipients": {scopeRead},
+
+	"MarketingContactsClient.Upsert":      {scopeCreate, scopeUpdate},
+	"MarketingContactsClient.Delete":      {scopeDelete},
+	"MarketingContactsClient.DeleteAll":   {scopeDelete},
+	"MarketingContactsClient.Get":         {scopeRead},
+	"MarketingContactsClient.GetByEmails": {scopeRead},
+	"MarketingContactsClient.Count":       {scopeRead},
+	"MarketingContactsClient.Sample":      {scopeRead},
+
+	"Job.Status": {scopeRead},
+
+	"MarketingContactsClient.Search": {scopeRead},
+
+	"FieldDefinitionsClient.Create":     {scopeCreate},
+	"FieldDefinitionsClient.List":       {scopeRead},
+	"FieldDefinitionsClient.UpdateName": {scopeUpdate},
+	"FieldDefinitionsClient.Delete":     {scopeDelete},
+
+	"MarketingListsClient.Create":         {scopeCreate},
+	"MarketingListsClient.Get":            {scopeRead},
+	"MarketingListsClient.Update":         {scopeUpdate},
+	"MarketingListsClient.Delete":         {scopeDelete},
+	"MarketingListsClient.ContactCount":   {scopeRead},
+	"MarketingListsClient.RemoveContacts": {scopeDelete},
+	"MarketingListsClient.Page":           {scopeRead},
+
+	"SegmentsV2Client.Create":  {scopeCreate},
+	"SegmentsV2Client.Get":     {scopeRead},
+	"SegmentsV2Client.List":    {scopeRead},
+	"SegmentsV2Client.Update":  {scopeUpdate},
+	"SegmentsV2Client.Delete":  {scopeDelete},
+	"SegmentsV2Client.Refresh": {scopeUpdate},
+
+	"MarketingContactsClient.Export": {scopeRead},
+	"ContactExport.Status":           {scopeRead},
+
+	"MarketingContactsClient.ImportCSV":        {scopeCreate, scopeUpdate, scopeRead},
+	"MarketingContactsClient.ImportRecipients": {scopeCreate, scopeUpdate, scopeRead},
+
+	"ContactStore.Upsert":         {scopeCreate, scopeRead, scopeUpdate},
+	"ContactStore.GetByEmail":     {scopeRead},
+	"ContactStore.Delete":         {scopeRead, scopeDelete},
+	"ContactStore.AddToList":      {scopeCreate, scopeRead, scopeUpdate},
+	"ContactStore.RemoveFromList": {scopeRead, scopeDelete},
+	"ContactStore.All":            {scopeRead},
+	"ContactStore.Search":         {scopeRead},
+
+	"Client.ExportList":    {scopeRead},
+	"Client.ExportSegment": {scopeRead},
+	"Client.ExportAll":     {scopeRead},
+	"Client.Backup":        {scopeRead},
+	"Client.Restore":       {scopeCreate, scopeRead, scopeUpdate},
+	"Client.PlanSpec":      {scopeRead},
+	"Plan.Apply":           {scopeCreate, scopeUpdate, scopeDelete},
+	"CopyList":             {scopeCreate, scopeRead},
+	"Client.Migrate":       {scopeCreate, scopeRead, scopeUpdate},
+	"Client.Forget":        {scopeRead, scopeDelete, scopeSuppress},
+
+	"Client.AddGlobalSuppressions": {scopeSuppress},
+}
+
+// ScopeError reports the scopes which an API key is missing, keyed by the operation which needs them.
+type ScopeError struct {
+	Missing map[string][]string
+}
+
+func (e *ScopeError) Error() string {
+	var operations []string
+
+	for operation := range e.Missing {
+		operations = append(operations, operation)
+	}
+
+	sort.Strings(operations)
+
+	var b strings.Builder
+
+	b.WriteString("contacts: api key is missing scopes:")
+
+	for _, operation := range operations {
+		fmt.Fprintf(&b, " %s requires %s;", operation, strings.Join(e.Missing[operation], ", "))
+	}
+
+	return strings.TrimSuffix(b.String(), ";")
+}
+
+type scopesResponse struct {
+	Scopes []string `json:"scopes"`
+}
+
+// Scopes lists the scopes granted to the Client's API key.
+//
+// https://sendgrid.com/docs/API_Reference/Web_API_v3/API_Keys/api_key_permissions_list.html
+func (c *Client) Scopes(ctx context.Context) ([]string, error) {
+	var resp *scopesResponse
+
+	err := c.makeRequest(ctx, "Client.Scopes", http.MethodGet, "/scopes", nil, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Scopes, nil
+}
+
+// VerifyScopes checks that the Client's API key has every scope needed by the given operations, named as
+// "RecipientClient.Add", "ListsClient.AddRecipientsByIDs" etc. If no operations are given, every operation
+// is checked. A *ScopeError listing the missing scopes per operation is returned if any are missing.
+func (c *Client) VerifyScopes(ctx context.Context, operations ...string) error {
+	if len(operations) == 0 {
+		for operation := range requiredScopes {
+			operations = append(operations, operation)
+		}
+	}
+
+	for _, operation := range operations {
+		if _, ok := requiredScopes[operation]; !ok {
+			return fmt.Errorf("contacts: unknown operation: %s", operation)
+		}
+	}
+
+	scopes, err := c.Scopes(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	granted := make(map[string]bool, len(scopes))
+
+	for _, scope := range scopes {
+		granted[scope] = true
+	}
+
+	missing := make(map[string][]string)
+
+	for _, operation := range operations {
+		for _, scope := range requiredScopes[operation] {
+			if !granted[scope] {
+				missing[operation] = append(missing[operation], scope)
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		return &ScopeError{Missing: missing}
+	}
+
+	return nil
+}
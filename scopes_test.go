@@ -0,0 +1,46 @@
+package contacts
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClient_Scopes(t *testing.T) {
+	scopes, err := client.Scopes(context.Background())
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(scopes) == 0 {
+		t.Fail()
+	}
+}
+
+func TestClient_VerifyScopes(t *testing.T) {
+	err := client.VerifyScopes(context.Background(), "RecipientClient.Add", "ListsClient.AddRecipientsByIDs")
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = client.VerifyScopes(context.Background(), "NotAClient.Operation")
+
+	if err == nil {
+		t.Fail()
+	}
+}
+
+func TestScopeError_Error(t *testing.T) {
+	err := &ScopeError{Missing: map[string][]string{
+		"RecipientClient.Delete": {scopeDelete},
+		"RecipientClient.Add":    {scopeCreate},
+	}}
+
+	want := "contacts: api key is missing scopes: RecipientClient.Add requires marketing_campaigns.create; RecipientClient.Delete requires marketing_campaigns.delete"
+
+	if err.Error() != want {
+		t.Error(err.Error())
+	}
+}
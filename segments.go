@@ -2,7 +2,11 @@ package contacts
 
 import (
 	"fmt"
+	"iter"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Segment struct {
@@ -13,10 +17,10 @@ type Segment struct {
 }
 
 type Condition struct {
-	Field    string `json:"field"`
-	Value    string `json:"value"`
-	Operator string `json:"operator"`
-	AndOr    string `json:"and_or"`
+	Field    string `json:"field" yaml:"field"`
+	Value    string `json:"value" yaml:"value"`
+	Operator string `json:"operator" yaml:"operator"`
+	AndOr    string `json:"and_or" yaml:"and_or"`
 }
 
 type SegmentsClient struct {
@@ -30,7 +34,7 @@ type SegmentsClient struct {
 //
 // https://sendgrid.com/docs/API_Reference/Web_API_v3/Marketing_Campaigns/contactdb.html#Create-a-Segment-POST
 func (c *SegmentsClient) Create(segment *Segment) error {
-	return c.client.makeRequest(http.MethodPost, "/contactdb/segments", segment, &segment)
+	return c.client.makeRequest(c.client.context(), "SegmentsClient.Create", http.MethodPost, "/contactdb/segments", segment, &segment)
 }
 
 type listSegmentsResponse struct {
@@ -43,7 +47,7 @@ type listSegmentsResponse struct {
 func (c *SegmentsClient) List() ([]*Segment, error) {
 	var resp *listSegmentsResponse
 
-	err := c.client.makeRequest(http.MethodGet, "/contactdb/segments", nil, &resp)
+	err := c.client.makeRequest(c.client.context(), "SegmentsClient.List", http.MethodGet, "/contactdb/segments", nil, &resp)
 
 	if err != nil {
 		return nil, err
@@ -58,7 +62,7 @@ func (c *SegmentsClient) List() ([]*Segment, error) {
 func (c *SegmentsClient) Get(segmentID uint) (*Segment, error) {
 	var segment *Segment
 
-	err := c.client.makeRequest(http.MethodGet, fmt.Sprintf("/contactdb/segments/%d", segmentID), nil, &segment)
+	err := c.client.makeRequest(c.client.context(), "SegmentsClient.Get", http.MethodGet, fmt.Sprintf("/contactdb/segments/%d", segmentID), nil, &segment)
 
 	if err != nil {
 		return nil, err
@@ -71,23 +75,23 @@ func (c *SegmentsClient) Get(segmentID uint) (*Segment, error) {
 //
 // https://sendgrid.com/docs/API_Reference/Web_API_v3/Marketing_Campaigns/contactdb.html#Update-a-Segment-PATCH
 func (c *SegmentsClient) Update(segment *Segment) error {
-	return c.client.makeRequest(http.MethodPatch, fmt.Sprintf("/contactdb/segments/%d", segment.ID), segment, &segment)
+	return c.client.makeRequest(c.client.context(), "SegmentsClient.Update", http.MethodPatch, fmt.Sprintf("/contactdb/segments/%d", segment.ID), segment, &segment)
 }
 
 // Delete a Segment
 //
 // https://sendgrid.com/docs/API_Reference/Web_API_v3/Marketing_Campaigns/contactdb.html#Delete-a-Segment-DELETE
 func (c *SegmentsClient) Delete(segmentID uint) error {
-	return c.client.makeRequest(http.MethodDelete, fmt.Sprintf("/contactdb/segments/%d", segmentID), nil, nil)
+	return c.client.makeRequest(c.client.context(), "SegmentsClient.Delete", http.MethodDelete, fmt.Sprintf("/contactdb/segments/%d", segmentID), nil, nil)
 }
 
 // ListRecipients on a Segment
 //
 // https://sendgrid.com/docs/API_Reference/Web_API_v3/Marketing_Campaigns/contactdb.html#List-Recipients-On-a-Segment-GET
 func (c *SegmentsClient) ListRecipients(segmentID, pageSize, page uint) ([]*Recipient, error) {
-	var resp *listRecipientsResponse
+	var resp listRecipientsResponse
 
-	err := c.client.makeRequest(http.MethodGet, fmt.Sprintf("/contactdb/segments/%d/recipients?page_size=%d&page=%d", segmentID, pageSize, page), nil, &resp)
+	err := c.client.makeRequest(c.client.context(), "SegmentsClient.ListRecipients", http.MethodGet, fmt.Sprintf("/contactdb/segments/%d/recipients?page_size=%d&page=%d", segmentID, pageSize, page), nil, &resp)
 
 	if err != nil {
 		return nil, err
@@ -95,3 +99,162 @@ func (c *SegmentsClient) ListRecipients(segmentID, pageSize, page uint) ([]*Reci
 
 	return resp.Recipients, nil
 }
+
+// ListRecipientsFunc calls fn with each Recipient on a page of a Segment as it is read from the response.
+// If fn returns an error, ListRecipientsFunc stops and returns it.
+//
+// https://sendgrid.com/docs/API_Reference/Web_API_v3/Marketing_Campaigns/contactdb.html#List-Recipients-On-a-Segment-GET
+func (c *SegmentsClient) ListRecipientsFunc(segmentID, pageSize, page uint, fn func(*Recipient) error) error {
+	return c.client.makeRequest(c.client.context(), "SegmentsClient.ListRecipients", http.MethodGet, fmt.Sprintf("/contactdb/segments/%d/recipients?page_size=%d&page=%d", segmentID, pageSize, page), nil, &listRecipientsResponse{each: fn})
+}
+
+// AllRecipients iterates over every Recipient on a Segment, fetching them a page at a time. Iteration stops
+// at the first error.
+func (c *SegmentsClient) AllRecipients(segmentID uint) iter.Seq2[*Recipient, error] {
+	return pageRecipients(func(page uint, fn func(*Recipient) error) error {
+		return c.ListRecipientsFunc(segmentID, maxPageSize, page, fn)
+	})
+}
+
+// engagementFields are legacy segment fields holding engagement data, which isn't returned with a Recipient
+// and has no equivalent in new API contact data.
+var engagementFields = map[string]bool{
+	"last_emailed":        true,
+	"last_clicked":        true,
+	"last_opened":         true,
+	"campaign_identifier": true,
+}
+
+// Matches reports whether a Recipient on the Lists with the given IDs is a member of the Segment, evaluating
+// its conditions locally. Conditions are combined with AND taking precedence over OR. Conditions on
+// engagement data, such as last_opened, can't be evaluated as Recipients don't include it; if the result
+// depends on them, known is false and matched should be ignored.
+func (s *Segment) Matches(r *Recipient, listIDs []uint) (matched, known bool) {
+	isMember := func(listID uint) bool {
+		for _, id := range listIDs {
+			if id == listID {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if s.ListID != 0 && !isMember(uint(s.ListID)) {
+		return false, true
+	}
+
+	if len(s.Conditions) == 0 {
+		return true, true
+	}
+
+	// each group of ANDed conditions is false if any condition is false, and otherwise unknown if any
+	// condition is unknown
+	anyTrue, anyUnknown := false, false
+	groupFalse, groupUnknown := false, false
+
+	endGroup := func() {
+		switch {
+		case groupFalse:
+		case groupUnknown:
+			anyUnknown = true
+		default:
+			anyTrue = true
+		}
+
+		groupFalse, groupUnknown = false, false
+	}
+
+	for i, condition := range s.Conditions {
+		if i > 0 && strings.EqualFold(condition.AndOr, "or") {
+			endGroup()
+		}
+
+		switch {
+		case engagementFields[condition.Field]:
+			groupUnknown = true
+		case condition.Field == "list_id":
+			id, err := strconv.ParseUint(condition.Value, 10, 0)
+			groupFalse = groupFalse || !(err == nil && isMember(uint(id)) == (condition.Operator != "ne"))
+		default:
+			groupFalse = groupFalse || !condition.matches(r.fieldValue(condition.Field))
+		}
+	}
+
+	endGroup()
+
+	if anyTrue {
+		return true, true
+	}
+
+	return false, !anyUnknown
+}
+
+func (c Condition) matches(actual interface{}) bool {
+	if c.Operator == "contains" {
+		return strings.Contains(strings.ToLower(fmt.Sprint(actual)), strings.ToLower(c.Value))
+	}
+
+	cmp := compareValues(actual, c.Value)
+
+	switch c.Operator {
+	case "eq":
+		return cmp == 0
+	case "ne":
+		return cmp != 0
+	case "lt":
+		return cmp < 0
+	case "gt":
+		return cmp > 0
+	default:
+		return false
+	}
+}
+
+// compareValues compares a Recipient's field value with a condition's value, numerically or as dates if
+// both can be read that way, and otherwise as case-insensitive strings.
+func compareValues(actual interface{}, want string) int {
+	if actual == nil {
+		actual = ""
+	}
+
+	a, aok := numericValue(actual)
+	w, wok := numericValue(want)
+
+	if aok && wok {
+		switch {
+		case a < w:
+			return -1
+		case a > w:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return strings.Compare(strings.ToLower(fmt.Sprint(actual)), strings.ToLower(want))
+}
+
+// numericValue reads a number, or a date as a unix timestamp.
+func numericValue(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f, true
+		}
+
+		for _, layout := range dateLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return float64(t.Unix()), true
+			}
+		}
+	}
+
+	return 0, false
+}
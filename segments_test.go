@@ -0,0 +1,56 @@
+package contacts
+
+import "testing"
+
+func TestSegment_Matches(t *testing.T) {
+	r := &Recipient{Email: "match@example.com", LastName: "Miller", CustomFields: []CustomField{
+		{Name: "age", Value: float64(42)},
+		{Name: "joined", Value: float64(1420070400)}, // 01/01/2015
+	}}
+
+	tests := []struct {
+		name    string
+		segment *Segment
+		listIDs []uint
+		want    bool
+		unknown bool
+	}{
+		{"no conditions", &Segment{}, nil, true, false},
+		{"eq", &Segment{Conditions: []Condition{{Field: "last_name", Value: "miller", Operator: "eq"}}}, nil, true, false},
+		{"ne", &Segment{Conditions: []Condition{{Field: "last_name", Value: "Miller", Operator: "ne"}}}, nil, false, false},
+		{"gt number", &Segment{Conditions: []Condition{{Field: "age", Value: "40", Operator: "gt"}}}, nil, true, false},
+		{"lt date", &Segment{Conditions: []Condition{{Field: "joined", Value: "06/01/2015", Operator: "lt"}}}, nil, true, false},
+		{"contains", &Segment{Conditions: []Condition{{Field: "email", Value: "EXAMPLE", Operator: "contains"}}}, nil, true, false},
+		{"and", &Segment{Conditions: []Condition{
+			{Field: "last_name", Value: "Miller", Operator: "eq"},
+			{Field: "age", Value: "50", Operator: "gt", AndOr: "and"},
+		}}, nil, false, false},
+		{"or", &Segment{Conditions: []Condition{
+			{Field: "age", Value: "50", Operator: "gt"},
+			{Field: "last_name", Value: "Miller", Operator: "eq", AndOr: "or"},
+		}}, nil, true, false},
+		{"missing field", &Segment{Conditions: []Condition{{Field: "shoe_size", Value: "9", Operator: "gt"}}}, nil, false, false},
+		{"engagement", &Segment{Conditions: []Condition{{Field: "last_clicked", Value: "01/02/2015", Operator: "gt"}}}, nil, false, true},
+		{"engagement and false", &Segment{Conditions: []Condition{
+			{Field: "last_opened", Value: "01/02/2015", Operator: "gt"},
+			{Field: "age", Value: "50", Operator: "gt", AndOr: "and"},
+		}}, nil, false, false},
+		{"engagement or true", &Segment{Conditions: []Condition{
+			{Field: "last_opened", Value: "01/02/2015", Operator: "gt"},
+			{Field: "age", Value: "40", Operator: "gt", AndOr: "or"},
+		}}, nil, true, false},
+		{"not on list", &Segment{ListID: 5}, []uint{4}, false, false},
+		{"on list", &Segment{ListID: 5}, []uint{4, 5}, true, false},
+		{"list_id condition", &Segment{Conditions: []Condition{{Field: "list_id", Value: "4", Operator: "eq"}}}, []uint{4}, true, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, known := test.segment.Matches(r, test.listIDs)
+
+			if known == test.unknown || (known && got != test.want) {
+				t.Errorf("got %v (known %v), want %v (unknown %v)", got, known, test.want, test.unknown)
+			}
+		})
+	}
+}
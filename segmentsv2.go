@@ -0,0 +1,301 @@
+package contacts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotExpressible is wrapped by the error returned by Segment.SGQL for a Condition which has no SGQL
+// equivalent, such as one on legacy engagement data.
+var ErrNotExpressible = errors.New("contacts: condition is not expressible in SGQL")
+
+// SegmentV2 is a segment in the new Marketing Campaigns API, defined by an SGQL query.
+type SegmentV2 struct {
+	ID             string         `json:"id,omitempty"`
+	Name           string         `json:"name"`
+	QueryDSL       string         `json:"query_dsl"`
+	ParentListIDs  []string       `json:"parent_list_ids,omitempty"`
+	ContactsCount  int            `json:"contacts_count"`
+	ContactsSample []*Contact     `json:"contacts_sample,omitempty"`
+	Status         *SegmentStatus `json:"status,omitempty"`
+	CreatedAt      *time.Time     `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time     `json:"updated_at,omitempty"`
+
+	// SampleUpdatedAt and NextSampleUpdate are when the contacts in the segment were last and will next be
+	// re-evaluated. Refresh re-evaluates them sooner, up to MaxRefreshes times a day.
+	SampleUpdatedAt  *time.Time `json:"sample_updated_at,omitempty"`
+	NextSampleUpdate *time.Time `json:"next_sample_update,omitempty"`
+	RefreshesUsed    int        `json:"refreshes_used"`
+	MaxRefreshes     int        `json:"max_refreshes"`
+}
+
+// SegmentStatus reports whether a SegmentV2's query is valid.
+type SegmentStatus struct {
+	QueryValidation string `json:"query_validation"`
+	ErrorMessage    string `json:"error_message,omitempty"`
+}
+
+// SegmentQueryDSL renders a Query as the query_dsl of a SegmentV2.
+func SegmentQueryDSL(where Query) (string, error) {
+	sgql, err := BuildSGQL(where)
+
+	if err != nil {
+		return "", err
+	}
+
+	return "SELECT contact_id, updated_at FROM contact_data WHERE " + sgql, nil
+}
+
+// SegmentsV2Client provides methods for managing SegmentV2s in the new Marketing Campaigns API.
+type SegmentsV2Client struct {
+	client *Client
+}
+
+type segmentV2Request struct {
+	Name          string   `json:"name,omitempty"`
+	QueryDSL      string   `json:"query_dsl,omitempty"`
+	ParentListIDs []string `json:"parent_list_ids,omitempty"`
+}
+
+// Create a Segment of the Contacts matching where, optionally scoped to a parent List.
+//
+// https://docs.sendgrid.com/api-reference/segmenting-contacts-v2/create-segment
+func (c *SegmentsV2Client) Create(ctx context.Context, name, parentListID string, where Query) (*SegmentV2, error) {
+	dsl, err := SegmentQueryDSL(where)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req := segmentV2Request{Name: name, QueryDSL: dsl}
+
+	if parentListID != "" {
+		req.ParentListIDs = []string{parentListID}
+	}
+
+	var segment *SegmentV2
+
+	err = c.client.makeRequest(ctx, "SegmentsV2Client.Create", http.MethodPost, "/marketing/segments/2.0", req, &segment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return segment, nil
+}
+
+// Get (Retrieve) a Segment, optionally with a sample of its Contacts.
+//
+// https://docs.sendgrid.com/api-reference/segmenting-contacts-v2/get-segment-by-id
+func (c *SegmentsV2Client) Get(ctx context.Context, segmentID string, contactsSample bool) (*SegmentV2, error) {
+	var segment *SegmentV2
+
+	err := c.client.makeRequest(ctx, "SegmentsV2Client.Get", http.MethodGet, "/marketing/segments/2.0/"+url.PathEscape(segmentID)+"?contacts_sample="+strconv.FormatBool(contactsSample), nil, &segment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return segment, nil
+}
+
+type listSegmentsV2Response struct {
+	Results []*SegmentV2 `json:"results"`
+}
+
+// List all Segments, or only those scoped to any of the given parent Lists.
+//
+// https://docs.sendgrid.com/api-reference/segmenting-contacts-v2/get-list-of-segments
+func (c *SegmentsV2Client) List(ctx context.Context, parentListIDs ...string) ([]*SegmentV2, error) {
+	path := "/marketing/segments/2.0"
+
+	if len(parentListIDs) > 0 {
+		path += "?" + url.Values{"parent_list_ids": {strings.Join(parentListIDs, ",")}}.Encode()
+	}
+
+	var resp *listSegmentsV2Response
+
+	err := c.client.makeRequest(ctx, "SegmentsV2Client.List", http.MethodGet, path, nil, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Results, nil
+}
+
+// Update a Segment's name and query. An empty name or nil query is left unchanged.
+//
+// https://docs.sendgrid.com/api-reference/segmenting-contacts-v2/update-segment
+func (c *SegmentsV2Client) Update(ctx context.Context, segmentID, name string, where Query) (*SegmentV2, error) {
+	req := segmentV2Request{Name: name}
+
+	if where != nil {
+		dsl, err := SegmentQueryDSL(where)
+
+		if err != nil {
+			return nil, err
+		}
+
+		req.QueryDSL = dsl
+	}
+
+	var segment *SegmentV2
+
+	err := c.client.makeRequest(ctx, "SegmentsV2Client.Update", http.MethodPatch, "/marketing/segments/2.0/"+url.PathEscape(segmentID), req, &segment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return segment, nil
+}
+
+// Delete a Segment. Its Contacts are not deleted.
+//
+// https://docs.sendgrid.com/api-reference/segmenting-contacts-v2/delete-segment
+func (c *SegmentsV2Client) Delete(ctx context.Context, segmentID string) error {
+	return c.client.makeRequest(ctx, "SegmentsV2Client.Delete", http.MethodDelete, "/marketing/segments/2.0/"+url.PathEscape(segmentID), nil, nil)
+}
+
+type refreshSegmentRequest struct {
+	UserTimeZone string `json:"user_time_zone"`
+}
+
+// Refresh re-evaluates a Segment's Contacts now rather than at its next scheduled update. timeZone is the
+// IANA name, e.g. "Europe/London", in which relative dates in the query are evaluated. The refresh happens
+// asynchronously; the ID of the job is returned.
+//
+// https://docs.sendgrid.com/api-reference/segmenting-contacts-v2/manually-refresh-a-segment
+func (c *SegmentsV2Client) Refresh(ctx context.Context, segmentID, timeZone string) (string, error) {
+	var resp *jobResponse
+
+	err := c.client.makeRequest(ctx, "SegmentsV2Client.Refresh", http.MethodPost, "/marketing/segments/2.0/refresh/"+url.PathEscape(segmentID), refreshSegmentRequest{UserTimeZone: timeZone}, &resp)
+
+	if err != nil {
+		return "", err
+	}
+
+	return resp.JobID, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SGQL converts the Segment's Conditions to an SGQL Query, with AND taking precedence over OR as in the
+// legacy API. fieldTypes gives the type of each custom field, so that values are compared as numbers or
+// dates; other fields compare as text. listIDs maps legacy List IDs to new API List IDs for list_id
+// conditions. The Segment's own ListID is not included; use it as the parent List instead.
+func (s *Segment) SGQL(fieldTypes map[string]FieldType, listIDs map[uint]string) (Query, error) {
+	if len(s.Conditions) == 0 {
+		return nil, fmt.Errorf("%w: segment %q has no conditions", ErrNotExpressible, s.Name)
+	}
+
+	var groups []Query
+	var group []Query
+
+	for i, condition := range s.Conditions {
+		if i > 0 && strings.EqualFold(condition.AndOr, "or") {
+			groups = append(groups, And(group...))
+			group = nil
+		}
+
+		q, err := condition.sgql(fieldTypes, listIDs)
+
+		if err != nil {
+			return nil, err
+		}
+
+		group = append(group, q)
+	}
+
+	groups = append(groups, And(group...))
+
+	if len(groups) == 1 {
+		return groups[0], nil
+	}
+
+	return Or(groups...), nil
+}
+
+func (c Condition) sgql(fieldTypes map[string]FieldType, listIDs map[uint]string) (Query, error) {
+	if engagementFields[c.Field] {
+		return nil, fmt.Errorf("%w: %s is engagement data", ErrNotExpressible, c.Field)
+	}
+
+	if c.Field == "list_id" {
+		id, err := strconv.ParseUint(c.Value, 10, 0)
+
+		if err != nil {
+			return nil, fmt.Errorf("contacts: invalid list_id %q", c.Value)
+		}
+
+		newID, ok := listIDs[uint(id)]
+
+		if !ok {
+			return nil, fmt.Errorf("%w: list %d has no new ID", ErrNotExpressible, id)
+		}
+
+		switch c.Operator {
+		case "eq":
+			return InList(newID), nil
+		case "ne":
+			return Not(InList(newID)), nil
+		default:
+			return nil, fmt.Errorf("%w: operator %s on list_id", ErrNotExpressible, c.Operator)
+		}
+	}
+
+	fieldType := fieldTypes[c.Field]
+
+	if c.Field == "created_at" || c.Field == "updated_at" {
+		fieldType = FieldTypeDate
+	}
+
+	if c.Operator == "contains" {
+		if fieldType != "" && fieldType != FieldTypeText {
+			return nil, fmt.Errorf("%w: contains on %s field %s", ErrNotExpressible, fieldType, c.Field)
+		}
+
+		return Like(c.Field, "%"+likeEscaper.Replace(c.Value)+"%"), nil
+	}
+
+	value, err := conditionValue(fieldType, c.Value)
+
+	if err != nil {
+		return nil, fmt.Errorf("contacts: condition on %s: %w", c.Field, err)
+	}
+
+	switch c.Operator {
+	case "eq":
+		return Eq(c.Field, value), nil
+	case "ne":
+		return Ne(c.Field, value), nil
+	case "lt":
+		return Lt(c.Field, value), nil
+	case "gt":
+		return Gt(c.Field, value), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown operator %q", ErrNotExpressible, c.Operator)
+	}
+}
+
+// conditionValue converts a legacy condition value to an SGQL value of the field's type.
+func conditionValue(fieldType FieldType, value string) (interface{}, error) {
+	converted, err := convertFieldValue(fieldType, value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if fieldType == FieldTypeDate {
+		return time.Unix(converted.(int64), 0), nil
+	}
+
+	return converted, nil
+}
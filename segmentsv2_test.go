@@ -0,0 +1,66 @@
+package contacts
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSegment_SGQL(t *testing.T) {
+	segment := &Segment{Conditions: []Condition{
+		{Field: "age", Value: "30", Operator: "gt"},
+		{Field: "list_id", Value: "7", Operator: "eq", AndOr: "and"},
+		{Field: "email", Value: "50%_off", Operator: "contains", AndOr: "or"},
+		{Field: "created_at", Value: "01/02/2020", Operator: "lt", AndOr: "and"},
+	}}
+
+	q, err := segment.SGQL(map[string]FieldType{"age": FieldTypeNumber}, map[uint]string{7: "abc"})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	dsl, err := SegmentQueryDSL(q)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	want := `SELECT contact_id, updated_at FROM contact_data WHERE (age > 30 AND CONTAINS(list_ids, 'abc')) OR (email LIKE '%50\\%\\_off%' AND created_at < TIMESTAMP '2020-01-02T00:00:00Z')`
+
+	if dsl != want {
+		t.Errorf("got %s, want %s", dsl, want)
+	}
+
+	segment = &Segment{Conditions: []Condition{{Field: "last_opened", Value: "01/02/2020", Operator: "gt"}}}
+
+	if _, err := segment.SGQL(nil, nil); !errors.Is(err, ErrNotExpressible) {
+		t.Error(err)
+	}
+}
+
+func TestSegmentsV2Client(t *testing.T) {
+	ctx := context.Background()
+
+	segment, err := client.SegmentsV2().Create(ctx, "Gmail users", "", Like("email", "%@gmail.com"))
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	defer client.SegmentsV2().Delete(ctx, segment.ID)
+
+	got, err := client.SegmentsV2().Get(ctx, segment.ID, false)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if got.QueryDSL != segment.QueryDSL {
+		t.Fail()
+	}
+}
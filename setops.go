@@ -0,0 +1,158 @@
+package contacts
+
+import (
+	"errors"
+	"iter"
+	"sort"
+)
+
+// An Audience is a group of Recipients which can be combined with set operations: a ListAudience or a
+// SegmentAudience.
+type Audience interface {
+	members(c *Client) iter.Seq2[*Recipient, error]
+}
+
+// ListAudience is the Recipients on the List with the given ID.
+type ListAudience uint
+
+func (a ListAudience) members(c *Client) iter.Seq2[*Recipient, error] {
+	return c.Lists().AllRecipients(uint(a))
+}
+
+// SegmentAudience is the Recipients on the Segment with the given ID.
+type SegmentAudience uint
+
+func (a SegmentAudience) members(c *Client) iter.Seq2[*Recipient, error] {
+	return c.Segments().AllRecipients(uint(a))
+}
+
+// memberIDs calls fn with the ID of each member of an Audience.
+func (c *ListsClient) memberIDs(audience Audience, fn func(id string)) error {
+	for recipient, err := range audience.members(c.client) {
+		if err != nil {
+			return err
+		}
+
+		fn(recipient.ID)
+	}
+
+	return nil
+}
+
+// Union returns the IDs of the Recipients which are members of any of the Audiences, sorted.
+func (c *ListsClient) Union(audiences ...Audience) ([]string, error) {
+	set := make(map[string]bool)
+
+	for _, audience := range audiences {
+		err := c.memberIDs(audience, func(id string) {
+			set[id] = true
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sortedIDs(set), nil
+}
+
+// Intersection returns the IDs of the Recipients which are members of every one of the Audiences, sorted.
+func (c *ListsClient) Intersection(audiences ...Audience) ([]string, error) {
+	if len(audiences) == 0 {
+		return nil, errors.New("contacts: intersection of no audiences")
+	}
+
+	set := make(map[string]bool)
+
+	err := c.memberIDs(audiences[0], func(id string) {
+		set[id] = true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, audience := range audiences[1:] {
+		if len(set) == 0 {
+			break
+		}
+
+		next := make(map[string]bool)
+
+		err := c.memberIDs(audience, func(id string) {
+			if set[id] {
+				next[id] = true
+			}
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		set = next
+	}
+
+	return sortedIDs(set), nil
+}
+
+// Difference returns the IDs of the Recipients which are members of from but not of any of the other
+// Audiences, sorted.
+func (c *ListsClient) Difference(from Audience, subtract ...Audience) ([]string, error) {
+	set := make(map[string]bool)
+
+	err := c.memberIDs(from, func(id string) {
+		set[id] = true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, audience := range subtract {
+		if len(set) == 0 {
+			break
+		}
+
+		err := c.memberIDs(audience, func(id string) {
+			delete(set, id)
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sortedIDs(set), nil
+}
+
+// CreateWithRecipients creates a List and adds the given Recipients to it in batches, e.g. to materialise
+// the result of Union, Intersection or Difference.
+func (c *ListsClient) CreateWithRecipients(name string, recipientIDs []string) (*List, error) {
+	list, err := c.Create(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for start := 0; start < len(recipientIDs); start += maxBatchSize {
+		end := min(start+maxBatchSize, len(recipientIDs))
+
+		if err := c.AddRecipientsByIDs(list.ID, recipientIDs[start:end]...); err != nil {
+			return list, err
+		}
+	}
+
+	return list, nil
+}
+
+func sortedIDs(set map[string]bool) []string {
+	ids := make([]string, 0, len(set))
+
+	for id := range set {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
@@ -0,0 +1,54 @@
+package contacts
+
+import (
+	"testing"
+	"time"
+)
+
+func TestListsClient_SetOperations(t *testing.T) {
+	a := &Recipient{Email: "set.a@example.com"}
+	b := &Recipient{Email: "set.b@example.com"}
+	c := &Recipient{Email: "set.c@example.com"}
+
+	_, err := client.Recipients().Add(a, b, c)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	list1, err := client.Lists().CreateWithRecipients("set_list_1", []string{a.ID, b.ID})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	list2, err := client.Lists().CreateWithRecipients("set_list_2", []string{b.ID, c.ID})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	// adding recipients to lists takes some time it seems...
+	time.Sleep(time.Second * 5)
+
+	union, err := client.Lists().Union(ListAudience(list1.ID), ListAudience(list2.ID))
+
+	if err != nil || len(union) != 3 {
+		t.Error(err, union)
+	}
+
+	intersection, err := client.Lists().Intersection(ListAudience(list1.ID), ListAudience(list2.ID))
+
+	if err != nil || len(intersection) != 1 || intersection[0] != b.ID {
+		t.Error(err, intersection)
+	}
+
+	difference, err := client.Lists().Difference(ListAudience(list1.ID), ListAudience(list2.ID))
+
+	if err != nil || len(difference) != 1 || difference[0] != a.ID {
+		t.Error(err, difference)
+	}
+}
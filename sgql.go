@@ -0,0 +1,363 @@
+package contacts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Query is an SGQL expression for searching Contacts in the new Marketing Campaigns API. Build one with
+// the functions in this file rather than by formatting strings, so that field names are validated and
+// values are escaped.
+type Query interface {
+	writeSGQL(b *strings.Builder) error
+}
+
+var sgqlFieldPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// BuildSGQL renders a Query as SGQL.
+func BuildSGQL(q Query) (string, error) {
+	var b strings.Builder
+
+	if err := q.writeSGQL(&b); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+func writeField(b *strings.Builder, field string) error {
+	if !sgqlFieldPattern.MatchString(field) {
+		return fmt.Errorf("contacts: invalid SGQL field name %q", field)
+	}
+
+	b.WriteString(field)
+
+	return nil
+}
+
+var sgqlEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+func writeValue(b *strings.Builder, value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		b.WriteString("'")
+		sgqlEscaper.WriteString(b, v)
+		b.WriteString("'")
+	case time.Time:
+		b.WriteString("TIMESTAMP '")
+		b.WriteString(v.UTC().Format(time.RFC3339))
+		b.WriteString("'")
+	case int:
+		b.WriteString(strconv.Itoa(v))
+	case int64:
+		b.WriteString(strconv.FormatInt(v, 10))
+	case uint:
+		b.WriteString(strconv.FormatUint(uint64(v), 10))
+	case float64:
+		b.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+	case bool:
+		b.WriteString(strings.ToUpper(strconv.FormatBool(v)))
+	default:
+		return fmt.Errorf("contacts: unsupported SGQL value %v (%T)", value, value)
+	}
+
+	return nil
+}
+
+type comparison struct {
+	field    string
+	operator string
+	value    interface{}
+}
+
+func (q comparison) writeSGQL(b *strings.Builder) error {
+	if err := writeField(b, q.field); err != nil {
+		return err
+	}
+
+	b.WriteString(" " + q.operator + " ")
+
+	return writeValue(b, q.value)
+}
+
+// Eq matches Contacts whose field equals value. Values may be strings, numbers, bools or time.Times.
+func Eq(field string, value interface{}) Query {
+	return comparison{field, "=", value}
+}
+
+// Ne matches Contacts whose field does not equal value.
+func Ne(field string, value interface{}) Query {
+	return comparison{field, "!=", value}
+}
+
+// Lt matches Contacts whose field is less than value.
+func Lt(field string, value interface{}) Query {
+	return comparison{field, "<", value}
+}
+
+// Le matches Contacts whose field is less than or equal to value.
+func Le(field string, value interface{}) Query {
+	return comparison{field, "<=", value}
+}
+
+// Gt matches Contacts whose field is greater than value.
+func Gt(field string, value interface{}) Query {
+	return comparison{field, ">", value}
+}
+
+// Ge matches Contacts whose field is greater than or equal to value.
+func Ge(field string, value interface{}) Query {
+	return comparison{field, ">=", value}
+}
+
+// Like matches Contacts whose field matches pattern, in which % matches any characters and _ any one.
+func Like(field, pattern string) Query {
+	return comparison{field, "LIKE", pattern}
+}
+
+// NotLike matches Contacts whose field does not match pattern.
+func NotLike(field, pattern string) Query {
+	return comparison{field, "NOT LIKE", pattern}
+}
+
+// Before matches Contacts whose date field is before t.
+func Before(field string, t time.Time) Query {
+	return comparison{field, "<", t}
+}
+
+// After matches Contacts whose date field is after t.
+func After(field string, t time.Time) Query {
+	return comparison{field, ">", t}
+}
+
+type in struct {
+	field  string
+	not    bool
+	values []interface{}
+}
+
+func (q in) writeSGQL(b *strings.Builder) error {
+	if len(q.values) == 0 {
+		return fmt.Errorf("contacts: SGQL IN on %s with no values", q.field)
+	}
+
+	if err := writeField(b, q.field); err != nil {
+		return err
+	}
+
+	if q.not {
+		b.WriteString(" NOT")
+	}
+
+	b.WriteString(" IN (")
+
+	for i, value := range q.values {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		if err := writeValue(b, value); err != nil {
+			return err
+		}
+	}
+
+	b.WriteString(")")
+
+	return nil
+}
+
+// In matches Contacts whose field equals any of values.
+func In(field string, values ...interface{}) Query {
+	return in{field: field, values: values}
+}
+
+// NotIn matches Contacts whose field equals none of values.
+func NotIn(field string, values ...interface{}) Query {
+	return in{field: field, not: true, values: values}
+}
+
+type function struct {
+	name  string
+	field string
+	value interface{}
+}
+
+func (q function) writeSGQL(b *strings.Builder) error {
+	b.WriteString(q.name + "(")
+
+	if err := writeField(b, q.field); err != nil {
+		return err
+	}
+
+	b.WriteString(", ")
+
+	if err := writeValue(b, q.value); err != nil {
+		return err
+	}
+
+	b.WriteString(")")
+
+	return nil
+}
+
+// Contains matches Contacts whose array field, such as alternate_emails, contains value.
+func Contains(field string, value interface{}) Query {
+	return function{"CONTAINS", field, value}
+}
+
+// InList matches Contacts on the List with the given ID.
+func InList(listID string) Query {
+	return Contains("list_ids", listID)
+}
+
+type null struct {
+	field string
+	not   bool
+}
+
+func (q null) writeSGQL(b *strings.Builder) error {
+	if err := writeField(b, q.field); err != nil {
+		return err
+	}
+
+	if q.not {
+		b.WriteString(" IS NOT NULL")
+	} else {
+		b.WriteString(" IS NULL")
+	}
+
+	return nil
+}
+
+// IsNull matches Contacts with no value for field.
+func IsNull(field string) Query {
+	return null{field: field}
+}
+
+// IsNotNull matches Contacts with a value for field.
+func IsNotNull(field string) Query {
+	return null{field: field, not: true}
+}
+
+type withinLast struct {
+	field string
+	days  int
+}
+
+func (q withinLast) writeSGQL(b *strings.Builder) error {
+	if err := writeField(b, q.field); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(b, " > TIMESTAMP_SUB(CURRENT_TIMESTAMP(), INTERVAL %d DAY)", q.days)
+
+	return nil
+}
+
+// WithinLast matches Contacts whose date field is within the last number of days.
+func WithinLast(field string, days int) Query {
+	return withinLast{field, days}
+}
+
+type group struct {
+	operator string
+	queries  []Query
+}
+
+func (q group) writeSGQL(b *strings.Builder) error {
+	if len(q.queries) == 0 {
+		return fmt.Errorf("contacts: SGQL %s of no queries", q.operator)
+	}
+
+	for i, query := range q.queries {
+		if i > 0 {
+			b.WriteString(" " + q.operator + " ")
+		}
+
+		if err := writeOperand(b, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeOperand writes a query, parenthesised if it is a compound, so that grouping never relies on
+// operator precedence.
+func writeOperand(b *strings.Builder, q Query) error {
+	switch q := q.(type) {
+	case group:
+		if len(q.queries) == 1 {
+			return writeOperand(b, q.queries[0])
+		}
+	case not:
+	default:
+		return q.writeSGQL(b)
+	}
+
+	b.WriteString("(")
+
+	if err := q.writeSGQL(b); err != nil {
+		return err
+	}
+
+	b.WriteString(")")
+
+	return nil
+}
+
+// And matches Contacts matching all of queries.
+func And(queries ...Query) Query {
+	return group{"AND", queries}
+}
+
+// Or matches Contacts matching any of queries.
+func Or(queries ...Query) Query {
+	return group{"OR", queries}
+}
+
+type not struct {
+	query Query
+}
+
+func (q not) writeSGQL(b *strings.Builder) error {
+	b.WriteString("NOT ")
+
+	return writeOperand(b, q.query)
+}
+
+// Not matches Contacts not matching query.
+func Not(query Query) Query {
+	return not{query}
+}
+
+type searchContactsRequest struct {
+	Query string `json:"query"`
+}
+
+// Search for Contacts matching an SGQL Query, returning up to 50 of them along with the total number of
+// matches.
+//
+// https://docs.sendgrid.com/api-reference/contacts/search-contacts
+func (c *MarketingContactsClient) Search(ctx context.Context, query Query) ([]*Contact, int, error) {
+	sgql, err := BuildSGQL(query)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var resp *contactsResultResponse
+
+	err = c.client.makeRequest(ctx, "MarketingContactsClient.Search", http.MethodPost, "/marketing/contacts/search", searchContactsRequest{Query: sgql}, &resp)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return resp.Result, resp.ContactCount, nil
+}
@@ -0,0 +1,53 @@
+package contacts
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBuildSGQL(t *testing.T) {
+	tests := []struct {
+		query Query
+		sgql  string
+	}{
+		{Eq("first_name", "O'Brien"), `first_name = 'O\'Brien'`},
+		{Like("email", `%\'%`), `email LIKE '%\\\'%'`},
+		{In("age", 30, 40), `age IN (30, 40)`},
+		{InList("abc"), `CONTAINS(list_ids, 'abc')`},
+		{After("created_at", time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)), `created_at > TIMESTAMP '2020-01-02T03:04:05Z'`},
+		{WithinLast("updated_at", 30), `updated_at > TIMESTAMP_SUB(CURRENT_TIMESTAMP(), INTERVAL 30 DAY)`},
+		{
+			And(Eq("country", "GB"), Or(Lt("age", 18), Not(IsNotNull("phone_number")))),
+			`country = 'GB' AND (age < 18 OR (NOT phone_number IS NOT NULL))`,
+		},
+	}
+
+	for _, test := range tests {
+		sgql, err := BuildSGQL(test.query)
+
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if sgql != test.sgql {
+			t.Errorf("got %s, want %s", sgql, test.sgql)
+		}
+	}
+
+	for _, query := range []Query{Eq("email = '' OR 1", "x"), In("age"), And(), Eq("age", []int{1})} {
+		if _, err := BuildSGQL(query); err == nil {
+			t.Errorf("expected error for %#v", query)
+		}
+	}
+}
+
+func TestMarketingContactsClient_Search(t *testing.T) {
+	_, _, err := client.MarketingContacts().Search(context.Background(), Like("email", "%@example.com"))
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,223 @@
+package contacts
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+const instrumentationName = "github.com/justapenguin/sendgrid-contacts-go"
+
+const (
+	attrOperation  = attribute.Key("sendgrid.operation")
+	attrBatchSize  = attribute.Key("sendgrid.batch_size")
+	attrErrorCount = attribute.Key("sendgrid.error_count")
+	attrMethod     = attribute.Key("http.request.method")
+	attrStatusCode = attribute.Key("http.response.status_code")
+	attrErrorType  = attribute.Key("error.type")
+)
+
+// errorCounter is implemented by responses which report a count of per-item errors, e.g. RecipientResponse.
+type errorCounter interface {
+	errorCount() int
+}
+
+func (c *Client) tracer() trace.Tracer {
+	if c.TracerProvider == nil {
+		return tracenoop.NewTracerProvider().Tracer(instrumentationName)
+	}
+
+	return c.TracerProvider.Tracer(instrumentationName)
+}
+
+// instrumentSet holds the metric instruments recorded for every request.
+type instrumentSet struct {
+	duration   metric.Float64Histogram
+	itemErrors metric.Int64Histogram
+	failures   metric.Int64Counter
+}
+
+func newInstrumentSet(provider metric.MeterProvider) *instrumentSet {
+	if provider == nil {
+		provider = metricnoop.NewMeterProvider()
+	}
+
+	meter := provider.Meter(instrumentationName)
+	set := &instrumentSet{}
+
+	var err error
+
+	set.duration, err = meter.Float64Histogram(
+		"sendgrid.contacts.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of requests made to the SendGrid API."),
+	)
+
+	if err != nil {
+		set.duration = metricnoop.Float64Histogram{}
+	}
+
+	set.itemErrors, err = meter.Int64Histogram(
+		"sendgrid.contacts.request.errors",
+		metric.WithUnit("{error}"),
+		metric.WithDescription("Number of items rejected by the SendGrid API per request."),
+	)
+
+	if err != nil {
+		set.itemErrors = metricnoop.Int64Histogram{}
+	}
+
+	set.failures, err = meter.Int64Counter(
+		"sendgrid.contacts.request.failures",
+		metric.WithUnit("{request}"),
+		metric.WithDescription("Number of requests to the SendGrid API which failed with an error status or in transport."),
+	)
+
+	if err != nil {
+		set.failures = metricnoop.Int64Counter{}
+	}
+
+	return set
+}
+
+// clientMetrics caches a Client's instruments, and is shared by the copies made by WithContext and
+// OnBehalfOf. The instruments are recreated only if MeterProvider is changed.
+type clientMetrics struct {
+	mu       sync.Mutex
+	provider metric.MeterProvider
+	set      *instrumentSet
+}
+
+// metricsInit guards the lazy creation of each Client's clientMetrics.
+var metricsInit sync.Mutex
+
+// sharedMetrics gets the Client's clientMetrics, creating them on first use so that a Client built as a
+// struct literal caches its instruments like one built by New.
+func (c *Client) sharedMetrics() *clientMetrics {
+	metricsInit.Lock()
+	defer metricsInit.Unlock()
+
+	if c.metrics == nil {
+		c.metrics = &clientMetrics{}
+	}
+
+	return c.metrics
+}
+
+func (c *Client) instruments() *instrumentSet {
+	metrics := c.sharedMetrics()
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	if metrics.set == nil || !sameProvider(metrics.provider, c.MeterProvider) {
+		metrics.provider, metrics.set = c.MeterProvider, newInstrumentSet(c.MeterProvider)
+	}
+
+	return metrics.set
+}
+
+// sameProvider compares MeterProviders without panicking on types which aren't comparable.
+func sameProvider(a, b metric.MeterProvider) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return reflect.TypeOf(a) == reflect.TypeOf(b) && reflect.TypeOf(a).Comparable() && a == b
+}
+
+func (c *Client) startSpan(ctx context.Context, operation, method string, data interface{}) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		attrOperation.String(operation),
+		attrMethod.String(method),
+	}
+
+	if n, ok := batchSize(data); ok {
+		attrs = append(attrs, attrBatchSize.Int(n))
+	}
+
+	return c.tracer().Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+func (c *Client) endSpan(ctx context.Context, span trace.Span, operation string, start time.Time, statusCode int, output interface{}, err error) {
+	defer span.End()
+
+	attrs := []attribute.KeyValue{attrOperation.String(operation)}
+
+	if statusCode != 0 {
+		attrs = append(attrs, attrStatusCode.Int(statusCode))
+		span.SetAttributes(attrStatusCode.Int(statusCode))
+	}
+
+	instruments := c.instruments()
+
+	instruments.duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+
+	if n, ok := errorCount(output); ok && err == nil {
+		span.SetAttributes(attrErrorCount.Int(n))
+		instruments.itemErrors.Record(ctx, int64(n), metric.WithAttributes(attrs...))
+	}
+
+	if err != nil && !errors.Is(err, errStopIteration) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		instruments.failures.Add(ctx, 1, metric.WithAttributes(append(attrs, attrErrorType.String(errorType(err)))...))
+	}
+}
+
+// errorType classifies a failed request: the status code of an APIError, or "_OTHER" for transport and
+// decoding failures.
+func errorType(err error) string {
+	var apiErr *APIError
+
+	if errors.As(err, &apiErr) {
+		return strconv.Itoa(apiErr.StatusCode)
+	}
+
+	return "_OTHER"
+}
+
+// batchSize returns the number of items in data, if data is a slice.
+func batchSize(data interface{}) (int, bool) {
+	if data == nil {
+		return 0, false
+	}
+
+	v := reflect.ValueOf(data)
+
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return 0, false
+	}
+
+	return v.Len(), true
+}
+
+// errorCount follows the pointers in output until it finds an errorCounter.
+func errorCount(output interface{}) (int, bool) {
+	if output == nil {
+		return 0, false
+	}
+
+	v := reflect.ValueOf(output)
+
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		if ec, ok := v.Interface().(errorCounter); ok {
+			return ec.errorCount(), true
+		}
+
+		v = v.Elem()
+	}
+
+	return 0, false
+}
@@ -0,0 +1,113 @@
+package contacts
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+)
+
+func TestBatchSize(t *testing.T) {
+	if n, ok := batchSize([]string{"a", "b"}); !ok || n != 2 {
+		t.Fail()
+	}
+
+	if _, ok := batchSize(&List{}); ok {
+		t.Fail()
+	}
+
+	if _, ok := batchSize(nil); ok {
+		t.Fail()
+	}
+}
+
+func TestErrorCount(t *testing.T) {
+	resp := &RecipientResponse{ErrorCount: 3}
+
+	if n, ok := errorCount(&resp); !ok || n != 3 {
+		t.Fail()
+	}
+
+	var list *List
+
+	if _, ok := errorCount(&list); ok {
+		t.Fail()
+	}
+}
+
+type countingMeterProvider struct {
+	metricnoop.MeterProvider
+	histograms int
+	failures   int64
+}
+
+func (p *countingMeterProvider) Meter(string, ...metric.MeterOption) metric.Meter {
+	return countingMeter{provider: p}
+}
+
+type countingMeter struct {
+	metricnoop.Meter
+	provider *countingMeterProvider
+}
+
+func (m countingMeter) Float64Histogram(string, ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	m.provider.histograms++
+
+	return metricnoop.Float64Histogram{}, nil
+}
+
+func (m countingMeter) Int64Counter(string, ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	return countingCounter{provider: m.provider}, nil
+}
+
+type countingCounter struct {
+	metricnoop.Int64Counter
+	provider *countingMeterProvider
+}
+
+func (c countingCounter) Add(_ context.Context, n int64, _ ...metric.AddOption) {
+	c.provider.failures += n
+}
+
+func TestClient_instruments(t *testing.T) {
+	provider := &countingMeterProvider{}
+
+	c := New("apikey")
+	c.MeterProvider = provider
+	c.HTTPClient = &http.Client{Transport: &unauthorizedTransport{validKey: "other"}}
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.WithContext(context.Background()).Lists().List(); err == nil {
+			t.Fail()
+		}
+	}
+
+	if provider.histograms != 1 {
+		t.Errorf("created %d duration histograms, want 1", provider.histograms)
+	}
+
+	if provider.failures != 3 {
+		t.Errorf("counted %d failures, want 3", provider.failures)
+	}
+
+	// a Client built as a struct literal caches its instruments too
+	provider = &countingMeterProvider{}
+
+	literal := &Client{APIKey: "apikey", MeterProvider: provider, HTTPClient: c.HTTPClient}
+
+	for i := 0; i < 3; i++ {
+		literal.WithContext(context.Background()).Lists().List()
+		literal.Lists().List()
+	}
+
+	if provider.histograms != 1 {
+		t.Errorf("created %d duration histograms for a struct literal Client, want 1", provider.histograms)
+	}
+
+	if errorType(&APIError{StatusCode: http.StatusUnauthorized}) != "401" || errorType(io.ErrUnexpectedEOF) != "_OTHER" {
+		t.Fail()
+	}
+}